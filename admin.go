@@ -0,0 +1,497 @@
+package redisClient
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// ServerInfo the parsed reply of INFO
+type ServerInfo struct {
+	// Sections holds every field by lower case section name,
+	// .e.g. Sections["server"]["redis_version"]
+	Sections    map[string]map[string]string
+	Memory      MemoryInfo
+	Replication ReplicationInfo
+	Stats       StatsInfo
+	// Keyspace by database index
+	Keyspace map[int]KeyspaceInfo
+}
+
+// MemoryInfo fields of the memory section, sizes are in bytes
+type MemoryInfo struct {
+	UsedMemory         int64
+	UsedMemoryRSS      int64
+	UsedMemoryPeak     int64
+	MaxMemory          int64
+	MaxMemoryPolicy    string
+	FragmentationRatio float64
+}
+
+// ReplicationInfo fields of the replication section
+type ReplicationInfo struct {
+	// Role is master or slave
+	Role             string
+	ConnectedSlaves  int64
+	MasterHost       string
+	MasterPort       int64
+	MasterLinkStatus string
+	MasterReplOffset int64
+}
+
+// StatsInfo fields of the stats and clients sections
+type StatsInfo struct {
+	ConnectedClients         int64
+	BlockedClients           int64
+	TotalConnectionsReceived int64
+	TotalCommandsProcessed   int64
+	InstantaneousOpsPerSec   int64
+	RejectedConnections      int64
+	ExpiredKeys              int64
+	EvictedKeys              int64
+	KeyspaceHits             int64
+	KeyspaceMisses           int64
+}
+
+// KeyspaceInfo fields of a database of the keyspace section
+type KeyspaceInfo struct {
+	Keys    int64
+	Expires int64
+	// AvgTTL is in milliseconds
+	AvgTTL int64
+}
+
+// SlowLogEntry an entry of SLOWLOG GET
+type SlowLogEntry struct {
+	// Addr is the address of the node which logged the entry
+	Addr     string
+	ID       int64
+	Time     time.Time
+	Duration time.Duration
+	Args     []string
+	// ClientAddr and ClientName are set by redis 4.0 and later
+	ClientAddr string
+	ClientName string
+}
+
+// ClientInfo a row of CLIENT LIST
+type ClientInfo struct {
+	// Addr is the address of the node the client is connected to
+	Addr string
+	ID   int64
+	// ClientAddr is the address of the client
+	ClientAddr string
+	Name       string
+	Age        time.Duration
+	Idle       time.Duration
+	Flags      string
+	DB         int64
+	Cmd        string
+	// Fields holds every field of the row
+	Fields map[string]string
+}
+
+var _ Admin = (*Client)(nil)
+
+// forEachNode runs fn like ForEachMaster, or ForEachNode when replicas is set
+func (r *Client) forEachNode(replicas bool, fn func(addr string, client *redis.Client) error) error {
+	return r.forEach(replicas, func(client *redis.Client) error {
+		return fn(client.Options().Addr, client)
+	})
+}
+
+// Info returns the INFO section of the masters with summed numbers, string
+// fields and Sections are only set for a single node. Use "" for the default section
+func (r *Client) Info(section string) (*ServerInfo, error) {
+	nodes, err := r.nodesInfo(false, section)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 1 {
+		for _, info := range nodes {
+			return info, nil
+		}
+	}
+	total := &ServerInfo{Sections: map[string]map[string]string{}, Keyspace: map[int]KeyspaceInfo{}}
+	for _, info := range nodes {
+		total.add(info)
+	}
+	return total, nil
+}
+
+// NodesInfo returns the INFO section of every node
+func (r *Client) NodesInfo(section string) (map[string]*ServerInfo, error) {
+	return r.nodesInfo(true, section)
+}
+
+func (r *Client) nodesInfo(replicas bool, section string) (map[string]*ServerInfo, error) {
+	var mu sync.Mutex
+	nodes := map[string]*ServerInfo{}
+	err := r.forEachNode(replicas, func(addr string, client *redis.Client) error {
+		var cmd *redis.StringCmd
+		if section == "" {
+			cmd = client.Info()
+		} else {
+			cmd = client.Info(section)
+		}
+		text, err := cmd.Result()
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		nodes[addr] = ParseInfo(text)
+		return nil
+	})
+	return nodes, err
+}
+
+// DBSize returns the number of keys of the masters
+func (r *Client) DBSize() (int64, error) {
+	nodes, err := r.nodesDBSize(false)
+	var total int64
+	for _, size := range nodes {
+		total += size
+	}
+	return total, err
+}
+
+// NodesDBSize returns the number of keys of every node
+func (r *Client) NodesDBSize() (map[string]int64, error) {
+	return r.nodesDBSize(true)
+}
+
+func (r *Client) nodesDBSize(replicas bool) (map[string]int64, error) {
+	var mu sync.Mutex
+	nodes := map[string]int64{}
+	err := r.forEachNode(replicas, func(addr string, client *redis.Client) error {
+		size, err := client.DBSize().Result()
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		nodes[addr] = size
+		return nil
+	})
+	return nodes, err
+}
+
+// ConfigGet returns the params matching parameter which have the same value
+// on every node, use NodesConfigGet to see the differing ones
+func (r *Client) ConfigGet(parameter string) (map[string]string, error) {
+	nodes, err := r.NodesConfigGet(parameter)
+	if err != nil {
+		return nil, err
+	}
+	var config map[string]string
+	for _, params := range nodes {
+		if config == nil {
+			config = params
+			continue
+		}
+		for key, value := range config {
+			if params[key] != value {
+				delete(config, key)
+			}
+		}
+	}
+	if config == nil {
+		config = map[string]string{}
+	}
+	return config, nil
+}
+
+// NodesConfigGet returns the params matching parameter of every node
+func (r *Client) NodesConfigGet(parameter string) (map[string]map[string]string, error) {
+	var mu sync.Mutex
+	nodes := map[string]map[string]string{}
+	err := r.forEachNode(true, func(addr string, client *redis.Client) error {
+		values, err := client.ConfigGet(parameter).Result()
+		if err != nil {
+			return err
+		}
+		params := make(map[string]string, len(values)/2)
+		for i := 0; i+1 < len(values); i += 2 {
+			params[fmt.Sprint(values[i])] = fmt.Sprint(values[i+1])
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		nodes[addr] = params
+		return nil
+	})
+	return nodes, err
+}
+
+// SlowLog returns the n latest entries of every node, latest first
+func (r *Client) SlowLog(n int64) ([]SlowLogEntry, error) {
+	nodes, err := r.NodesSlowLog(n)
+	if err != nil {
+		return nil, err
+	}
+	entries := []SlowLogEntry{}
+	for _, nodeEntries := range nodes {
+		entries = append(entries, nodeEntries...)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Time.After(entries[j].Time)
+	})
+	if n >= 0 && int64(len(entries)) > n {
+		entries = entries[:n]
+	}
+	return entries, nil
+}
+
+// NodesSlowLog returns the n latest entries of each node, a negative n returns all
+func (r *Client) NodesSlowLog(n int64) (map[string][]SlowLogEntry, error) {
+	var mu sync.Mutex
+	nodes := map[string][]SlowLogEntry{}
+	err := r.forEachNode(true, func(addr string, client *redis.Client) error {
+		cmd := redis.NewSliceCmd("slowlog", "get", n)
+		client.Process(cmd)
+		values, err := cmd.Result()
+		if err != nil {
+			return err
+		}
+		entries, err := parseSlowLog(addr, values)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		nodes[addr] = entries
+		return nil
+	})
+	return nodes, err
+}
+
+// Time returns the time of a node, use NodesTime to compare the clocks
+func (r *Client) Time() (time.Time, error) {
+	client, ok := r.cmd().(redis.Cmdable)
+	if !ok {
+		return time.Time{}, ErrNotImplemented
+	}
+	return client.Time().Result()
+}
+
+// NodesTime returns the time of every node
+func (r *Client) NodesTime() (map[string]time.Time, error) {
+	var mu sync.Mutex
+	nodes := map[string]time.Time{}
+	err := r.forEachNode(true, func(addr string, client *redis.Client) error {
+		tm, err := client.Time().Result()
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		nodes[addr] = tm
+		return nil
+	})
+	return nodes, err
+}
+
+// ClientList returns the clients connected to every node
+func (r *Client) ClientList() ([]ClientInfo, error) {
+	nodes, err := r.NodesClientList()
+	if err != nil {
+		return nil, err
+	}
+	addrs := make([]string, 0, len(nodes))
+	for addr := range nodes {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+	clients := []ClientInfo{}
+	for _, addr := range addrs {
+		clients = append(clients, nodes[addr]...)
+	}
+	return clients, nil
+}
+
+// NodesClientList returns the clients connected to each node
+func (r *Client) NodesClientList() (map[string][]ClientInfo, error) {
+	var mu sync.Mutex
+	nodes := map[string][]ClientInfo{}
+	err := r.forEachNode(true, func(addr string, client *redis.Client) error {
+		text, err := client.ClientList().Result()
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		nodes[addr] = ParseClientList(addr, text)
+		return nil
+	})
+	return nodes, err
+}
+
+// ParseInfo parses the reply of INFO
+func ParseInfo(text string) *ServerInfo {
+	info := &ServerInfo{Sections: map[string]map[string]string{}, Keyspace: map[int]KeyspaceInfo{}}
+	section := map[string]string{}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			section = map[string]string{}
+			info.Sections[strings.ToLower(strings.TrimSpace(line[1:]))] = section
+			continue
+		}
+		if i := strings.Index(line, ":"); i > 0 {
+			section[line[:i]] = line[i+1:]
+		}
+	}
+	memory, replication := info.Sections["memory"], info.Sections["replication"]
+	stats, clients := info.Sections["stats"], info.Sections["clients"]
+	info.Memory = MemoryInfo{
+		UsedMemory:         parseInt(memory["used_memory"]),
+		UsedMemoryRSS:      parseInt(memory["used_memory_rss"]),
+		UsedMemoryPeak:     parseInt(memory["used_memory_peak"]),
+		MaxMemory:          parseInt(memory["maxmemory"]),
+		MaxMemoryPolicy:    memory["maxmemory_policy"],
+		FragmentationRatio: parseFloat(memory["mem_fragmentation_ratio"]),
+	}
+	info.Replication = ReplicationInfo{
+		Role:             replication["role"],
+		ConnectedSlaves:  parseInt(replication["connected_slaves"]),
+		MasterHost:       replication["master_host"],
+		MasterPort:       parseInt(replication["master_port"]),
+		MasterLinkStatus: replication["master_link_status"],
+		MasterReplOffset: parseInt(replication["master_repl_offset"]),
+	}
+	info.Stats = StatsInfo{
+		ConnectedClients:         parseInt(clients["connected_clients"]),
+		BlockedClients:           parseInt(clients["blocked_clients"]),
+		TotalConnectionsReceived: parseInt(stats["total_connections_received"]),
+		TotalCommandsProcessed:   parseInt(stats["total_commands_processed"]),
+		InstantaneousOpsPerSec:   parseInt(stats["instantaneous_ops_per_sec"]),
+		RejectedConnections:      parseInt(stats["rejected_connections"]),
+		ExpiredKeys:              parseInt(stats["expired_keys"]),
+		EvictedKeys:              parseInt(stats["evicted_keys"]),
+		KeyspaceHits:             parseInt(stats["keyspace_hits"]),
+		KeyspaceMisses:           parseInt(stats["keyspace_misses"]),
+	}
+	// db0:keys=1,expires=0,avg_ttl=0
+	for db, value := range info.Sections["keyspace"] {
+		index, err := strconv.Atoi(strings.TrimPrefix(db, "db"))
+		if err != nil {
+			continue
+		}
+		fields := parseFields(value, ",")
+		info.Keyspace[index] = KeyspaceInfo{
+			Keys:    parseInt(fields["keys"]),
+			Expires: parseInt(fields["expires"]),
+			AvgTTL:  parseInt(fields["avg_ttl"]),
+		}
+	}
+	return info
+}
+
+// add sums the numbers of o into i
+func (i *ServerInfo) add(o *ServerInfo) {
+	i.Memory.UsedMemory += o.Memory.UsedMemory
+	i.Memory.UsedMemoryRSS += o.Memory.UsedMemoryRSS
+	i.Memory.UsedMemoryPeak += o.Memory.UsedMemoryPeak
+	i.Memory.MaxMemory += o.Memory.MaxMemory
+	i.Replication.ConnectedSlaves += o.Replication.ConnectedSlaves
+	i.Stats.ConnectedClients += o.Stats.ConnectedClients
+	i.Stats.BlockedClients += o.Stats.BlockedClients
+	i.Stats.TotalConnectionsReceived += o.Stats.TotalConnectionsReceived
+	i.Stats.TotalCommandsProcessed += o.Stats.TotalCommandsProcessed
+	i.Stats.InstantaneousOpsPerSec += o.Stats.InstantaneousOpsPerSec
+	i.Stats.RejectedConnections += o.Stats.RejectedConnections
+	i.Stats.ExpiredKeys += o.Stats.ExpiredKeys
+	i.Stats.EvictedKeys += o.Stats.EvictedKeys
+	i.Stats.KeyspaceHits += o.Stats.KeyspaceHits
+	i.Stats.KeyspaceMisses += o.Stats.KeyspaceMisses
+	for db, keyspace := range o.Keyspace {
+		total := i.Keyspace[db]
+		total.Keys += keyspace.Keys
+		total.Expires += keyspace.Expires
+		i.Keyspace[db] = total
+	}
+}
+
+// ParseClientList parses the reply of CLIENT LIST of the node addr
+func ParseClientList(addr, text string) []ClientInfo {
+	clients := []ClientInfo{}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		fields := parseFields(line, " ")
+		clients = append(clients, ClientInfo{
+			Addr:       addr,
+			ID:         parseInt(fields["id"]),
+			ClientAddr: fields["addr"],
+			Name:       fields["name"],
+			Age:        time.Duration(parseInt(fields["age"])) * time.Second,
+			Idle:       time.Duration(parseInt(fields["idle"])) * time.Second,
+			Flags:      fields["flags"],
+			DB:         parseInt(fields["db"]),
+			Cmd:        fields["cmd"],
+			Fields:     fields,
+		})
+	}
+	return clients
+}
+
+// parseSlowLog parses the reply of SLOWLOG GET
+func parseSlowLog(addr string, values []interface{}) ([]SlowLogEntry, error) {
+	entries := make([]SlowLogEntry, 0, len(values))
+	for _, value := range values {
+		fields, ok := value.([]interface{})
+		if !ok || len(fields) < 4 {
+			return nil, fmt.Errorf("redis: unexpected slowlog entry %v", value)
+		}
+		id, _ := fields[0].(int64)
+		timestamp, _ := fields[1].(int64)
+		micros, _ := fields[2].(int64)
+		entry := SlowLogEntry{
+			Addr:     addr,
+			ID:       id,
+			Time:     time.Unix(timestamp, 0),
+			Duration: time.Duration(micros) * time.Microsecond,
+		}
+		args, _ := fields[3].([]interface{})
+		for _, arg := range args {
+			entry.Args = append(entry.Args, fmt.Sprint(arg))
+		}
+		if len(fields) >= 6 {
+			entry.ClientAddr, _ = fields[4].(string)
+			entry.ClientName, _ = fields[5].(string)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// parseFields parses key=value fields separated by sep
+func parseFields(text, sep string) map[string]string {
+	fields := map[string]string{}
+	for _, field := range strings.Split(text, sep) {
+		if i := strings.Index(field, "="); i > 0 {
+			fields[field[:i]] = field[i+1:]
+		}
+	}
+	return fields
+}
+
+func parseInt(value string) int64 {
+	n, _ := strconv.ParseInt(value, 10, 64)
+	return n
+}
+
+func parseFloat(value string) float64 {
+	f, _ := strconv.ParseFloat(value, 64)
+	return f
+}
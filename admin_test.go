@@ -0,0 +1,159 @@
+package redisClient_test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	redis "github.com/alauda/go-redis-client"
+)
+
+// resp encodes value as a redis reply, int64 as integer, string as bulk string
+func resp(value interface{}) string {
+	switch v := value.(type) {
+	case int64:
+		return fmt.Sprintf(":%d\r\n", v)
+	case string:
+		return fmt.Sprintf("$%d\r\n%s\r\n", len(v), v)
+	case []interface{}:
+		reply := fmt.Sprintf("*%d\r\n", len(v))
+		for _, e := range v {
+			reply += resp(e)
+		}
+		return reply
+	}
+	panic(fmt.Sprintf("unexpected reply %v", value))
+}
+
+func TestParseClientList(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []redis.ClientInfo
+	}{
+		{
+			name: "redis 3.2",
+			text: "id=2 addr=127.0.0.1:52555 fd=5 name= age=855 idle=0 flags=N db=0 sub=0 psub=0 multi=-1 qbuf=0 qbuf-free=32768 obl=0 oll=0 omem=0 events=r cmd=client\n",
+			want: []redis.ClientInfo{{Addr: "node:6379", ID: 2, ClientAddr: "127.0.0.1:52555", Age: 855 * time.Second, Flags: "N", Cmd: "client"}},
+		},
+		{
+			name: "redis 5 replica and named client",
+			text: "id=7 addr=10.0.0.12:40962 fd=9 name= age=3601 idle=1 flags=S db=0 sub=0 psub=0 multi=-1 qbuf=0 qbuf-free=0 obl=0 oll=0 omem=0 events=r cmd=replconf\n" +
+				"id=9 addr=10.0.0.31:51234 fd=11 name=worker-1 age=62 idle=12 flags=N db=3 sub=0 psub=0 multi=-1 qbuf=0 qbuf-free=0 obl=0 oll=0 omem=0 events=r cmd=blpop\n",
+			want: []redis.ClientInfo{
+				{Addr: "node:6379", ID: 7, ClientAddr: "10.0.0.12:40962", Age: 3601 * time.Second, Idle: time.Second, Flags: "S", Cmd: "replconf"},
+				{Addr: "node:6379", ID: 9, ClientAddr: "10.0.0.31:51234", Name: "worker-1", Age: 62 * time.Second, Idle: 12 * time.Second, Flags: "N", DB: 3, Cmd: "blpop"},
+			},
+		},
+		{
+			name: "redis 7",
+			text: "id=3 addr=127.0.0.1:57578 laddr=127.0.0.1:6379 fd=8 name= age=10 idle=0 flags=N db=2 sub=0 psub=0 ssub=0 multi=-1 qbuf=26 qbuf-free=20448 argv-mem=10 multi-mem=0 rbs=1024 rbp=0 obl=0 oll=0 omem=0 tot-mem=22298 events=r cmd=client|list user=default redir=-1 resp=2 lib-name= lib-ver=\r\n",
+			want: []redis.ClientInfo{{Addr: "node:6379", ID: 3, ClientAddr: "127.0.0.1:57578", Age: 10 * time.Second, Flags: "N", DB: 2, Cmd: "client|list"}},
+		},
+		{
+			name: "empty",
+			text: "",
+			want: []redis.ClientInfo{},
+		},
+	}
+	for _, test := range tests {
+		clients := redis.ParseClientList("node:6379", test.text)
+		for i := range clients {
+			if clients[i].Fields["fd"] == "" {
+				t.Errorf("%s: every field should be kept, got %v", test.name, clients[i].Fields)
+			}
+			clients[i].Fields = nil
+		}
+		if !reflect.DeepEqual(clients, test.want) {
+			t.Errorf("%s: got %+v, want %+v", test.name, clients, test.want)
+		}
+	}
+}
+
+func TestSlowLog(t *testing.T) {
+	tests := []struct {
+		name  string
+		reply []interface{}
+		want  []redis.SlowLogEntry
+	}{
+		{
+			name:  "redis 3.2",
+			reply: []interface{}{[]interface{}{int64(14), int64(1309448221), int64(15), []interface{}{"ping"}}},
+			want:  []redis.SlowLogEntry{{ID: 14, Time: time.Unix(1309448221, 0), Duration: 15 * time.Microsecond, Args: []string{"ping"}}},
+		},
+		{
+			name: "redis 4 and later, latest first",
+			reply: []interface{}{
+				[]interface{}{int64(2), int64(1521822036), int64(10126), []interface{}{"KEYS", "*"}, "127.0.0.1:58217", "worker-1"},
+				[]interface{}{int64(1), int64(1521822030), int64(20300), []interface{}{"DEL", "k1", "... (31 more arguments)"}, "127.0.0.1:58218", ""},
+			},
+			want: []redis.SlowLogEntry{
+				{ID: 2, Time: time.Unix(1521822036, 0), Duration: 10126 * time.Microsecond, Args: []string{"KEYS", "*"}, ClientAddr: "127.0.0.1:58217", ClientName: "worker-1"},
+				{ID: 1, Time: time.Unix(1521822030, 0), Duration: 20300 * time.Microsecond, Args: []string{"DEL", "k1", "... (31 more arguments)"}, ClientAddr: "127.0.0.1:58218"},
+			},
+		},
+		{
+			name:  "empty",
+			reply: []interface{}{},
+			want:  []redis.SlowLogEntry{},
+		},
+	}
+	for _, test := range tests {
+		reply := resp(test.reply)
+		server := newFakeServer(t, "tcp", "127.0.0.1:0", func(args []string) string {
+			if strings.ToLower(strings.Join(args, " ")) == "slowlog get 10" {
+				return reply
+			}
+			return "-ERR unknown command\r\n"
+		})
+		client := redis.NewClient(redis.Options{
+			Type:  redis.ClientNormal,
+			Hosts: []string{server.Addr()},
+		})
+		for i := range test.want {
+			test.want[i].Addr = server.Addr()
+		}
+		entries, err := client.SlowLog(10)
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+		} else if !reflect.DeepEqual(entries, test.want) {
+			t.Errorf("%s: got %+v, want %+v", test.name, entries, test.want)
+		}
+		client.Close()
+		server.Close()
+	}
+
+	server := newFakeServer(t, "tcp", "127.0.0.1:0", func(args []string) string {
+		return resp([]interface{}{[]interface{}{int64(1), int64(1521822030)}})
+	})
+	defer server.Close()
+	client := redis.NewClient(redis.Options{
+		Type:  redis.ClientNormal,
+		Hosts: []string{server.Addr()},
+	})
+	defer client.Close()
+	if _, err := client.SlowLog(10); err == nil {
+		t.Error("a truncated entry should be rejected")
+	}
+}
+
+func TestTime(t *testing.T) {
+	server := newFakeServer(t, "tcp", "127.0.0.1:0", func(args []string) string {
+		if strings.ToLower(args[0]) == "time" {
+			return resp([]interface{}{"1700000000", "250000"})
+		}
+		return "-ERR unknown command\r\n"
+	})
+	defer server.Close()
+	client := redis.NewClient(redis.Options{
+		Type:  redis.ClientNormal,
+		Hosts: []string{server.Addr()},
+	})
+	defer client.Close()
+	tm, err := client.Time()
+	if err != nil || !tm.Equal(time.Unix(1700000000, 250000000)) {
+		t.Error("bad time:", tm, err)
+	}
+}
@@ -6,7 +6,7 @@ package redisClient
 func AutoConfigRedisClient(rwType RWType) (*Client, error) {
 	opts, err := customizedOptionsFromFullVariable(rwType)
 	if opts != nil {
-		return NewClient(*opts), err
+		return newAutoConfigClient(*opts, err)
 	}
 	return nil, err
 }
@@ -16,7 +16,7 @@ func AutoConfigRedisClient(rwType RWType) (*Client, error) {
 func AutoConfigRedisClientFromVolume(rwType RWType) (*Client, error) {
 	opts, err := customizedOptionsFromVolume(rwType)
 	if opts != nil {
-		return NewClient(*opts), err
+		return newAutoConfigClient(*opts, err)
 	}
 	return nil, err
 }
@@ -26,7 +26,17 @@ func AutoConfigRedisClientFromVolume(rwType RWType) (*Client, error) {
 func AutoConfigRedisClientFromEnv(rwType RWType) (*Client, error) {
 	opts, err := customizedOptionsFromEnv(rwType)
 	if opts != nil {
-		return NewClient(*opts), err
+		return newAutoConfigClient(*opts, err)
 	}
 	return nil, err
 }
+
+// newAutoConfigClient create redisclient and fail fast when options are
+// invalid or cluster slots are not fully covered
+func newAutoConfigClient(opts Options, err error) (*Client, error) {
+	client, clientErr := NewClientE(opts)
+	if clientErr != nil {
+		return nil, clientErr
+	}
+	return client, err
+}
@@ -0,0 +1,254 @@
+package redisClient
+
+import (
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// batchChunkSize is the maximum number of keys sent in one command of a batch
+const batchChunkSize = 100
+
+// batchCountScript runs the command ARGV[1] on each key and returns the counts,
+// it tells which keys of a slot group were deleted or exist
+const batchCountScript = `local counts = {}
+for i, key in ipairs(KEYS) do
+	counts[i] = redis.call(ARGV[1], key)
+end
+return counts`
+
+// BatchResult the result of one key of a batch, results are in the order of the keys
+type BatchResult struct {
+	// Key is the key without prefix
+	Key   string
+	Value string
+	// Missing is set when the key does not exist
+	Missing bool
+	Err     error
+}
+
+// BatchEntry a key to set in a batch
+type BatchEntry struct {
+	Key   string
+	Value interface{}
+	// TTL of the key, 0 means no expiration
+	TTL time.Duration
+}
+
+// batchGroups splits the indexes of prefixed keys into groups which can be
+// sent in one command: by slot for a cluster, by key for a ring whose
+// shards are unknown, else a single group. Groups have at most batchChunkSize keys
+func (r *Client) batchGroups(keys []string) [][]int {
+	var groups [][]int
+	switch {
+	case r.IsCluster():
+		bySlot := map[int]int{}
+		for i, key := range keys {
+			slot := hashSlot(key)
+			g, ok := bySlot[slot]
+			if !ok || len(groups[g]) >= batchChunkSize {
+				g = len(groups)
+				bySlot[slot] = g
+				groups = append(groups, nil)
+			}
+			groups[g] = append(groups[g], i)
+		}
+	case r.IsRing():
+		for i := range keys {
+			groups = append(groups, []int{i})
+		}
+	default:
+		for i := range keys {
+			if i%batchChunkSize == 0 {
+				groups = append(groups, nil)
+			}
+			groups[len(groups)-1] = append(groups[len(groups)-1], i)
+		}
+	}
+	return groups
+}
+
+// batchNodes splits the indexes of groups by the cluster master serving
+// their slot, read from CLUSTER SLOTS. Other client types, or a cluster
+// whose slots can not be read, have a single node
+func (r *Client) batchNodes(keys []string, groups [][]int) [][]int {
+	var slots []redis.ClusterSlot
+	if client, ok := r.cmd().(redis.Cmdable); ok && r.IsCluster() && len(groups) > 1 {
+		slots, _ = client.ClusterSlots().Result()
+	}
+	var nodes [][]int
+	byAddr := map[string]int{}
+	for g, group := range groups {
+		addr := ""
+		slot := hashSlot(keys[group[0]])
+		for _, s := range slots {
+			if slot >= s.Start && slot <= s.End && len(s.Nodes) > 0 {
+				addr = s.Nodes[0].Addr
+				break
+			}
+		}
+		n, ok := byAddr[addr]
+		if !ok {
+			n = len(nodes)
+			byAddr[addr] = n
+			nodes = append(nodes, nil)
+		}
+		nodes[n] = append(nodes[n], g)
+	}
+	return nodes
+}
+
+// batchExec queues the commands of each group with queue, the groups of a
+// node are sent in one pipeline and the nodes are sent concurrently
+func (r *Client) batchExec(keys []string, groups [][]int, queue func(pipe redis.Pipeliner, g int)) {
+	var wg sync.WaitGroup
+	for _, node := range r.batchNodes(keys, groups) {
+		wg.Add(1)
+		go func(node []int) {
+			defer wg.Done()
+			pipe := r.cmd().Pipeline()
+			defer pipe.Close()
+			for _, g := range node {
+				queue(pipe, g)
+			}
+			pipe.Exec()
+		}(node)
+	}
+	wg.Wait()
+}
+
+// groupKeys returns the keys of group
+func groupKeys(keys []string, group []int) []string {
+	res := make([]string, len(group))
+	for j, i := range group {
+		res[j] = keys[i]
+	}
+	return res
+}
+
+// firstErr returns the first error of results
+func firstErr(results []BatchResult) error {
+	for _, result := range results {
+		if result.Err != nil {
+			return result.Err
+		}
+	}
+	return nil
+}
+
+// BatchGet gets the values of keys with one MGET per slot, the MGETs of
+// each cluster master are pipelined and the masters are queried
+// concurrently. The error is the first error of the results
+func (r *Client) BatchGet(keys ...string) ([]BatchResult, error) {
+	results := make([]BatchResult, len(keys))
+	prefixed := r.ks(keys...)
+	groups := r.batchGroups(prefixed)
+	cmds := make([]*redis.SliceCmd, len(groups))
+	r.batchExec(prefixed, groups, func(pipe redis.Pipeliner, g int) {
+		cmds[g] = pipe.MGet(groupKeys(prefixed, groups[g])...)
+	})
+	for g, group := range groups {
+		values, err := cmds[g].Result()
+		for j, i := range group {
+			results[i].Key = keys[i]
+			switch {
+			case err != nil:
+				results[i].Err = err
+			case j >= len(values) || values[j] == nil:
+				results[i].Missing = true
+			default:
+				results[i].Value, _ = values[j].(string)
+			}
+		}
+	}
+	return results, firstErr(results)
+}
+
+// BatchSet sets the values of entries with one MSET per slot, entries
+// having a TTL are set one by one with SET in the pipeline of their master
+func (r *Client) BatchSet(entries ...BatchEntry) ([]BatchResult, error) {
+	results := make([]BatchResult, len(entries))
+	prefixed := make([]string, len(entries))
+	var plain []int
+	var groups [][]int
+	for i, entry := range entries {
+		prefixed[i] = r.k(entry.Key)
+		results[i].Key = entry.Key
+		if entry.TTL > 0 {
+			groups = append(groups, []int{i})
+		} else {
+			plain = append(plain, i)
+		}
+	}
+	for _, group := range r.batchGroups(groupKeys(prefixed, plain)) {
+		for j, p := range group {
+			group[j] = plain[p]
+		}
+		groups = append(groups, group)
+	}
+	cmds := make([]*redis.StatusCmd, len(groups))
+	r.batchExec(prefixed, groups, func(pipe redis.Pipeliner, g int) {
+		if entry := entries[groups[g][0]]; entry.TTL > 0 {
+			cmds[g] = pipe.Set(prefixed[groups[g][0]], entry.Value, entry.TTL)
+			return
+		}
+		pairs := make([]interface{}, 0, 2*len(groups[g]))
+		for _, i := range groups[g] {
+			pairs = append(pairs, prefixed[i], entries[i].Value)
+		}
+		cmds[g] = pipe.MSet(pairs...)
+	})
+	for g, group := range groups {
+		for _, i := range group {
+			results[i].Err = cmds[g].Err()
+		}
+	}
+	return results, firstErr(results)
+}
+
+// BatchDel deletes keys with one command per slot, Missing is set for the
+// keys which did not exist
+func (r *Client) BatchDel(keys ...string) ([]BatchResult, error) {
+	return r.batchCount(keys, "del")
+}
+
+// BatchExists checks keys with one command per slot, Missing is set for
+// the keys which do not exist
+func (r *Client) BatchExists(keys ...string) ([]BatchResult, error) {
+	return r.batchCount(keys, "exists")
+}
+
+// batchCount runs the counting command name on keys, a key is missing when
+// its count is 0. A group of several keys is sent as one batchCountScript
+// as the count of a multi-key command does not tell which keys are missing
+func (r *Client) batchCount(keys []string, name string) ([]BatchResult, error) {
+	results := make([]BatchResult, len(keys))
+	prefixed := r.ks(keys...)
+	groups := r.batchGroups(prefixed)
+	cmds := make([]*redis.Cmd, len(groups))
+	r.batchExec(prefixed, groups, func(pipe redis.Pipeliner, g int) {
+		group := groupKeys(prefixed, groups[g])
+		if len(group) == 1 {
+			cmds[g] = redis.NewCmd(name, group[0])
+			pipe.Process(cmds[g])
+			return
+		}
+		cmds[g] = pipe.Eval(batchCountScript, group, name)
+	})
+	for g, group := range groups {
+		val, err := cmds[g].Result()
+		counts, ok := val.([]interface{})
+		if !ok {
+			counts = []interface{}{val}
+		}
+		for j, i := range group {
+			results[i] = BatchResult{Key: keys[i], Err: err}
+			if err == nil && j < len(counts) {
+				count, _ := counts[j].(int64)
+				results[i].Missing = count == 0
+			}
+		}
+	}
+	return results, firstErr(results)
+}
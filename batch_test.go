@@ -0,0 +1,172 @@
+package redisClient_test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	redis "github.com/alauda/go-redis-client"
+)
+
+// fakeStore answers the commands of the batches from an in-memory store
+type fakeStore struct {
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func (s *fakeStore) reply(args []string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	count := func(name, key string) int {
+		_, ok := s.values[key]
+		if ok && name == "del" {
+			delete(s.values, key)
+		}
+		if ok {
+			return 1
+		}
+		return 0
+	}
+	switch name := strings.ToLower(args[0]); name {
+	case "set":
+		s.values[args[1]] = args[2]
+		return "+OK\r\n"
+	case "mset":
+		for i := 1; i+1 < len(args); i += 2 {
+			s.values[args[i]] = args[i+1]
+		}
+		return "+OK\r\n"
+	case "mget":
+		reply := fmt.Sprintf("*%d\r\n", len(args)-1)
+		for _, key := range args[1:] {
+			if value, ok := s.values[key]; ok {
+				reply += fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+			} else {
+				reply += "$-1\r\n"
+			}
+		}
+		return reply
+	case "del", "exists":
+		return fmt.Sprintf(":%d\r\n", count(name, args[1]))
+	case "eval":
+		// EVAL script numkeys key... command
+		keys := args[3 : len(args)-1]
+		reply := fmt.Sprintf("*%d\r\n", len(keys))
+		for _, key := range keys {
+			reply += fmt.Sprintf(":%d\r\n", count(args[len(args)-1], key))
+		}
+		return reply
+	}
+	return "-ERR unknown command\r\n"
+}
+
+func TestBatch(t *testing.T) {
+	store := &fakeStore{values: map[string]string{}}
+	server := newFakeServer(t, "tcp", "127.0.0.1:0", store.reply)
+	defer server.Close()
+	client := redis.NewClient(redis.Options{
+		Type:      redis.ClientNormal,
+		Hosts:     []string{server.Addr()},
+		KeyPrefix: "app:",
+	})
+	defer client.Close()
+
+	if _, err := client.BatchSet(
+		redis.BatchEntry{Key: "a", Value: "1"},
+		redis.BatchEntry{Key: "b", Value: "2", TTL: time.Minute},
+		redis.BatchEntry{Key: "c", Value: "3"},
+	); err != nil {
+		t.Fatal(err)
+	}
+	if !server.received("mset app:a 1 app:c 3") || !server.received("set app:b 2 ex 60") {
+		t.Error("keys without TTL should be set with MSET, the others with SET EX")
+	}
+
+	results, err := client.BatchGet("c", "missing", "a", "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := []redis.BatchResult{{Key: "c", Value: "3"}, {Key: "missing", Missing: true}, {Key: "a", Value: "1"}, {Key: "b", Value: "2"}}
+	if !reflect.DeepEqual(results, exp) {
+		t.Errorf("got %+v, want %+v", results, exp)
+	}
+
+	for _, batch := range []func(keys ...string) ([]redis.BatchResult, error){client.BatchExists, client.BatchDel} {
+		results, err = batch("a", "missing", "b")
+		if err != nil {
+			t.Fatal(err)
+		}
+		exp = []redis.BatchResult{{Key: "a"}, {Key: "missing", Missing: true}, {Key: "b"}}
+		if !reflect.DeepEqual(results, exp) {
+			t.Errorf("got %+v, want %+v", results, exp)
+		}
+	}
+	if results, _ = client.BatchExists("a", "c"); !results[0].Missing || results[1].Missing {
+		t.Error("BatchDel should delete only its keys, got:", results)
+	}
+	if server.received("del ") || server.received("exists ") {
+		t.Error("the keys of one group should be counted by one command")
+	}
+}
+
+func TestBatchCluster(t *testing.T) {
+	store := &fakeStore{values: map[string]string{
+		"app:{a}1": "a1", "app:{a}2": "a2", "app:{b}1": "b1", "app:{c}1": "c1",
+	}}
+	// the MGET of a node waits for the MGET of the other node
+	var once [2]sync.Once
+	arrived := [2]chan struct{}{make(chan struct{}), make(chan struct{})}
+	servers := newFakeCluster(t, func(node int, args []string) string {
+		if strings.ToLower(args[0]) == "mget" {
+			once[node].Do(func() { close(arrived[node]) })
+			select {
+			case <-arrived[1-node]:
+			case <-time.After(time.Second):
+				return "-ERR the nodes are not queried concurrently\r\n"
+			}
+		}
+		return store.reply(args)
+	})
+	for _, server := range servers {
+		defer server.Close()
+	}
+	client := redis.NewClient(redis.Options{
+		Type:      redis.ClientCluster,
+		Hosts:     []string{servers[0].Addr()},
+		KeyPrefix: "app:",
+	})
+	defer client.Close()
+
+	// slot of a is 15495 on node 1, slots of b and c are 3300 and 7365 on node 0
+	results, err := client.BatchGet("{b}1", "{a}1", "{c}1", "{a}2", "{a}3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := []redis.BatchResult{
+		{Key: "{b}1", Value: "b1"}, {Key: "{a}1", Value: "a1"}, {Key: "{c}1", Value: "c1"},
+		{Key: "{a}2", Value: "a2"}, {Key: "{a}3", Missing: true},
+	}
+	if !reflect.DeepEqual(results, exp) {
+		t.Errorf("got %+v, want %+v", results, exp)
+	}
+	if !servers[1].received("mget app:{a}1 app:{a}2 app:{a}3") {
+		t.Error("the keys of one slot should be sent in one MGET")
+	}
+	if !servers[0].received("mget app:{b}1") || !servers[0].received("mget app:{c}1") {
+		t.Error("each slot should have its MGET")
+	}
+
+	results, err = client.BatchExists("{a}1", "{b}1", "{a}3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !results[2].Missing || results[0].Missing || results[1].Missing {
+		t.Error("bad results:", results)
+	}
+	if !servers[1].received("eval ") || !servers[0].received("exists app:{b}1") {
+		t.Error("a group of several keys should be counted by a script, a single key by EXISTS")
+	}
+}
@@ -3,6 +3,8 @@ package redisClient
 import (
 	"errors"
 	"fmt"
+	"io"
+	"net"
 	"sync"
 	"time"
 
@@ -16,41 +18,131 @@ const RedisNil = redis.Nil
 
 // Client a struct representing the redis client
 type Client struct {
+	// mu guards the fields swapped by Reload
+	mu        sync.RWMutex
 	opts      Options
 	client    Commander
 	fmtString string
+	// hashTag extracts the hash tag of keys, nil when keys are not tagged
+	hashTag func(key string) string
+	// parent is set for a namespace, which shares the connection of its parent
+	parent    *Client
+	namespace string
 }
 
 // NewClient Initiates a new client
+// When opts.Type is ClientAuto the hosts are probed to detect the client type.
+// When TLS is requested for a client type which does not support it, every
+// command fails instead of being sent in plaintext, use NewClientE to get the error
 func NewClient(opts Options) *Client {
+	opts = resolveAutoType(opts)
 	r := &Client{opts: opts}
-	switch opts.Type {
+	switch {
+	// TLS is only supported by the normal client, never fall back to plaintext
+	case opts.TLSConfig != nil && !opts.Type.supportsTLS():
+		err := fmt.Errorf("redis: TLS is not supported by %s client", opts.Type)
+		logrus.Error(err)
+		r.client = failingClient(err)
 	// Cluster client
-	case ClientCluster:
+	case opts.Type == ClientCluster:
 		r.client = redis.NewClusterClient(opts.GetClusterConfig())
+	// Sentinel failover client
+	case opts.Type == ClientSentinel:
+		r.client = redis.NewFailoverClient(opts.GetFailoverConfig())
+	// Ring client sharding keys over standalone instances
+	case opts.Type == ClientRing:
+		r.client = redis.NewRing(opts.GetRingConfig())
 	// Standard client also as default
-	case ClientNormal:
-		fallthrough
 	default:
 		r.client = redis.NewClient(opts.GetNormalConfig())
 	}
 	r.fmtString = opts.KeyPrefix + "%s"
+	r.hashTag = opts.hashTagFunc()
 	return r
 }
 
+// failingClient returns a client whose connections fail with err
+func failingClient(err error) Commander {
+	return redis.NewClient(&redis.Options{
+		Dialer: func() (net.Conn, error) {
+			return nil, err
+		},
+	})
+}
+
+// NewClientE validates the options and initiates a new client, cluster
+// slot coverage is checked unless SkipFullCoverCheck is set
+func NewClientE(opts Options) (*Client, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	r := NewClient(opts)
+	if opts.Type == ClientAuto {
+		// the detected type may not accept the options
+		if err := r.options().Validate(); err != nil {
+			r.Close()
+			return nil, err
+		}
+	}
+	if !opts.SkipFullCoverCheck {
+		if err := r.CheckClusterCoverage(); err != nil {
+			r.Close()
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Open validates the options, initiates a new client and pings the server
+func Open(opts Options) (*Client, error) {
+	r, err := NewClientE(opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.Ping().Err(); err != nil {
+		r.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+// ClientType returns the client type in use, the detected one for ClientAuto
+func (r *Client) ClientType() ClientType {
+	clientType := r.options().Type
+	if clientType == "" {
+		return ClientNormal
+	}
+	return clientType
+}
+
 // IsCluster determine whether client is a cluster model
 func (r *Client) IsCluster() bool {
-	return r.opts.Type == ClientCluster
+	return r.options().Type == ClientCluster
+}
+
+// IsSentinel determine whether client is a sentinel model
+func (r *Client) IsSentinel() bool {
+	return r.options().Type == ClientSentinel
+}
+
+// IsRing determine whether client is a ring model
+func (r *Client) IsRing() bool {
+	return r.options().Type == ClientRing
 }
 
 //Prefix return prefix+key
 func (r *Client) Prefix(key string) string {
-	return fmt.Sprintf(r.fmtString, key)
+	return r.k(key)
 }
 
-// Formats and retuns the key with the prefix
+// Formats and retuns the key with the prefix and the hash tag
 func (r *Client) k(key string) string {
-	return fmt.Sprintf(r.fmtString, key)
+	if r.parent != nil {
+		return r.parent.k(r.namespace + key)
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return fmt.Sprintf(r.fmtString, tagKey(key, r.hashTag))
 }
 
 // Formats and returns a set of keys using the prefix
@@ -62,478 +154,745 @@ func (r *Client) ks(key ...string) []string {
 	return keys
 }
 
+// Formats the keys of key/value pairs using the prefix,
+// returns the pairs and the prefixed keys
+func (r *Client) kPairs(pairs []interface{}) ([]interface{}, []string) {
+	prefixed := make([]interface{}, len(pairs))
+	keys := make([]string, 0, len(pairs)/2)
+	for i, v := range pairs {
+		if i%2 == 0 {
+			key := r.k(fmt.Sprint(v))
+			keys = append(keys, key)
+			v = key
+		}
+		prefixed[i] = v
+	}
+	return prefixed, keys
+}
+
+// Close closes the client, releasing any open resources,
+// it is a no-op for a namespace
+func (r *Client) Close() error {
+	if r.parent != nil {
+		return nil
+	}
+	if closer, ok := r.cmd().(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
 // GetClient returns the client
 func (r *Client) GetClient() Commander {
+	return r.cmd()
+}
+
+// cmd returns the underlying client, safe to call during Reload
+func (r *Client) cmd() Commander {
+	if r.parent != nil {
+		return r.parent.cmd()
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 	return r.client
 }
 
+// options returns the options in use, safe to call during Reload
+func (r *Client) options() Options {
+	if r.parent != nil {
+		return r.parent.options()
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.opts
+}
+
 // -------------- Pinger
 
 // Ping sends a Ping command
 func (r *Client) Ping() *redis.StatusCmd {
-	return r.client.Ping()
+	return r.cmd().Ping()
 }
 
 // -------------- Incrementer
 
 // Incr increments the key by 1
 func (r *Client) Incr(key string) *redis.IntCmd {
-	return r.client.Incr(r.k(key))
+	return r.cmd().Incr(r.k(key))
 }
 
 // IncrBy increments using a increment value
 func (r *Client) IncrBy(key string, value int64) *redis.IntCmd {
-	return r.client.IncrBy(r.k(key), value)
+	return r.cmd().IncrBy(r.k(key), value)
+}
+
+// IncrByFloat increments using a float increment value
+func (r *Client) IncrByFloat(key string, value float64) *redis.FloatCmd {
+	return r.cmd().IncrByFloat(r.k(key), value)
 }
 
 // -------------- Decrementer
 
 // Decr decrements the key by 1
 func (r *Client) Decr(key string) *redis.IntCmd {
-	return r.client.Decr(r.k(key))
+	return r.cmd().Decr(r.k(key))
 }
 
 // DecrBy decrements using a increment value
 func (r *Client) DecrBy(key string, value int64) *redis.IntCmd {
-	return r.client.DecrBy(r.k(key), value)
+	return r.cmd().DecrBy(r.k(key), value)
 }
 
 // -------------- Expirer
 
 // Expire expire method
 func (r *Client) Expire(key string, expiration time.Duration) *redis.BoolCmd {
-	return r.client.Expire(r.k(key), expiration)
+	return r.cmd().Expire(r.k(key), expiration)
 }
 
 // ExpireAt expireat method
 func (r *Client) ExpireAt(key string, tm time.Time) *redis.BoolCmd {
-	return r.client.ExpireAt(r.k(key), tm)
+	return r.cmd().ExpireAt(r.k(key), tm)
 }
 
 // Persist persist command
 func (r *Client) Persist(key string) *redis.BoolCmd {
-	return r.client.Persist(r.k(key))
+	return r.cmd().Persist(r.k(key))
 }
 
 // PExpire redis command
 func (r *Client) PExpire(key string, expiration time.Duration) *redis.BoolCmd {
-	return r.client.PExpire(r.k(key), expiration)
+	return r.cmd().PExpire(r.k(key), expiration)
 }
 func (r *Client) PExpireAt(key string, tm time.Time) *redis.BoolCmd {
-	return r.client.PExpireAt(r.k(key), tm)
+	return r.cmd().PExpireAt(r.k(key), tm)
 }
 func (r *Client) PTTL(key string) *redis.DurationCmd {
-	return r.client.PTTL(r.k(key))
+	return r.cmd().PTTL(r.k(key))
 }
 func (r *Client) TTL(key string) *redis.DurationCmd {
-	return r.client.TTL(r.k(key))
+	return r.cmd().TTL(r.k(key))
 }
 
 // -------------- Getter
 
 // Exists exists command
 func (r *Client) Exists(key ...string) *redis.IntCmd {
-	return r.client.Exists(r.ks(key...)...)
+	keys := r.ks(key...)
+	if err := r.checkSlots(keys...); err != nil {
+		return redis.NewIntResult(0, err)
+	}
+	return r.cmd().Exists(keys...)
 }
 
 // Get get key value
 func (r *Client) Get(key string) *redis.StringCmd {
-	return r.client.Get(r.k(key))
+	return r.cmd().Get(r.k(key))
 }
 
 // GetBit getbit key value
 func (r *Client) GetBit(key string, offset int64) *redis.IntCmd {
-	return r.client.GetBit(r.k(key), offset)
+	return r.cmd().GetBit(r.k(key), offset)
 }
 
 // GetRange GetRange key value
 func (r *Client) GetRange(key string, start, end int64) *redis.StringCmd {
-	return r.client.GetRange(r.k(key), start, end)
+	return r.cmd().GetRange(r.k(key), start, end)
 }
 
 // GetSet getset command
 func (r *Client) GetSet(key string, value interface{}) *redis.StringCmd {
-	return r.client.GetSet(r.k(key), value)
+	return r.cmd().GetSet(r.k(key), value)
 }
 
-// MGetByPipeline gets multiple values from keys,Pipeline is used when
-// redis is a cluster,This means higher IO performance
+// MGetByPipeline gets multiple values from keys with BatchGet, values are
+// in the order of keys and missing keys have an empty value
 // params: keys ...string
 // return: []string, error
 func (r *Client) MGetByPipeline(keys ...string) ([]string, error) {
-
-	var res []string
-
-	if r.IsCluster() {
-		start := time.Now()
-		pipeLineLen := 100
-		pipeCount := len(keys)/pipeLineLen + 1
-		pipes := make([]redis.Pipeliner, pipeCount)
-		for i := 0; i < pipeCount; i++ {
-			pipes[i] = r.client.Pipeline()
-		}
-		for i, k := range keys {
-			p := pipes[i%pipeCount]
-			p.Get(r.k(k))
-		}
-		logrus.Debugf("process cost: %v", time.Since(start))
-		start = time.Now()
-		var wg sync.WaitGroup
-		var lock sync.Mutex
-		errors := make(chan error, pipeCount)
-		for _, p := range pipes {
-			p := p
-			wg.Add(1)
-			go func() {
-				defer wg.Done()
-				cmders, err := p.Exec()
-				if err != nil {
-					select {
-					case errors <- err:
-					default:
-					}
-					return
-				}
-				lock.Lock()
-				defer lock.Unlock()
-				for _, cmder := range cmders {
-					result, _ := cmder.(*redis.StringCmd).Result()
-					res = append(res, result)
-				}
-			}()
-		}
-		wg.Wait()
-		logrus.Debugf("exec cost: %v", time.Since(start))
-
-		if len(errors) > 0 {
-			return nil, <-errors
-		}
-
-		return res, nil
-	}
-
-	vals, err := r.client.MGet(keys...).Result()
-
-	if redis.Nil != err && nil != err {
+	results, err := r.BatchGet(keys...)
+	if err != nil {
 		return nil, err
 	}
-
-	for _, item := range vals {
-		res = append(res, fmt.Sprintf("%s", item))
+	res := make([]string, len(results))
+	for i, result := range results {
+		res[i] = result.Value
 	}
-
-	return res, err
+	return res, nil
 }
 
 // MGet Multiple get command
 func (r *Client) MGet(keys ...string) *redis.SliceCmd {
-	return r.client.MGet(r.ks(keys...)...)
+	keys = r.ks(keys...)
+	if err := r.checkSlots(keys...); err != nil {
+		return redis.NewSliceResult(nil, err)
+	}
+	return r.cmd().MGet(keys...)
 }
 
 // Dump dump command
 func (r *Client) Dump(key string) *redis.StringCmd {
-	return r.client.Dump(r.k(key))
+	return r.cmd().Dump(r.k(key))
+}
+
+// StrLen strlen command
+func (r *Client) StrLen(key string) *redis.IntCmd {
+	return r.cmd().StrLen(r.k(key))
 }
 
 // -------------- Hasher
 
 func (r *Client) HExists(key, field string) *redis.BoolCmd {
-	return r.client.HExists(r.k(key), field)
+	return r.cmd().HExists(r.k(key), field)
 }
 func (r *Client) HGet(key, field string) *redis.StringCmd {
-	return r.client.HGet(r.k(key), field)
+	return r.cmd().HGet(r.k(key), field)
 }
 func (r *Client) HGetAll(key string) *redis.StringStringMapCmd {
-	return r.client.HGetAll(r.k(key))
+	return r.cmd().HGetAll(r.k(key))
 }
 func (r *Client) HIncrBy(key, field string, incr int64) *redis.IntCmd {
-	return r.client.HIncrBy(r.k(key), field, incr)
+	return r.cmd().HIncrBy(r.k(key), field, incr)
 }
 func (r *Client) HIncrByFloat(key, field string, incr float64) *redis.FloatCmd {
-	return r.client.HIncrByFloat(r.k(key), field, incr)
+	return r.cmd().HIncrByFloat(r.k(key), field, incr)
 }
 func (r *Client) HKeys(key string) *redis.StringSliceCmd {
-	return r.client.HKeys(r.k(key))
+	return r.cmd().HKeys(r.k(key))
 }
 func (r *Client) HLen(key string) *redis.IntCmd {
-	return r.client.HLen(r.k(key))
+	return r.cmd().HLen(r.k(key))
 }
 func (r *Client) HMGet(key string, fields ...string) *redis.SliceCmd {
-	return r.client.HMGet(r.k(key), fields...)
+	return r.cmd().HMGet(r.k(key), fields...)
 }
 func (r *Client) HMSet(key string, fields map[string]interface{}) *redis.StatusCmd {
-	return r.client.HMSet(r.k(key), fields)
+	return r.cmd().HMSet(r.k(key), fields)
 }
 
 func (r *Client) HSet(key, field string, value interface{}) *redis.BoolCmd {
-	return r.client.HSet(r.k(key), field, value)
+	return r.cmd().HSet(r.k(key), field, value)
 }
 func (r *Client) HSetNX(key, field string, value interface{}) *redis.BoolCmd {
-	return r.client.HSetNX(r.k(key), field, value)
+	return r.cmd().HSetNX(r.k(key), field, value)
 }
 func (r *Client) HVals(key string) *redis.StringSliceCmd {
-	return r.client.HVals(r.k(key))
+	return r.cmd().HVals(r.k(key))
 }
 func (r *Client) HDel(key string, fields ...string) *redis.IntCmd {
-	return r.client.HDel(r.k(key), fields...)
+	return r.cmd().HDel(r.k(key), fields...)
 }
 
 // -------------- Lister
 
 func (r *Client) LIndex(key string, index int64) *redis.StringCmd {
-	return r.client.LIndex(r.k(key), index)
+	return r.cmd().LIndex(r.k(key), index)
 }
 func (r *Client) LInsert(key, op string, pivot, value interface{}) *redis.IntCmd {
-	return r.client.LInsert(r.k(key), op, pivot, value)
+	return r.cmd().LInsert(r.k(key), op, pivot, value)
 }
 func (r *Client) LInsertAfter(key string, pivot, value interface{}) *redis.IntCmd {
-	return r.client.LInsertAfter(r.k(key), pivot, value)
+	return r.cmd().LInsertAfter(r.k(key), pivot, value)
 }
 func (r *Client) LInsertBefore(key string, pivot, value interface{}) *redis.IntCmd {
-	return r.client.LInsertBefore(r.k(key), pivot, value)
+	return r.cmd().LInsertBefore(r.k(key), pivot, value)
 }
 func (r *Client) LLen(key string) *redis.IntCmd {
-	return r.client.LLen(r.k(key))
+	return r.cmd().LLen(r.k(key))
 }
 func (r *Client) LPop(key string) *redis.StringCmd {
-	return r.client.LPop(r.k(key))
+	return r.cmd().LPop(r.k(key))
 }
 func (r *Client) LPush(key string, values ...interface{}) *redis.IntCmd {
-	return r.client.LPush(r.k(key), values...)
+	return r.cmd().LPush(r.k(key), values...)
 }
 func (r *Client) LPushX(key string, value interface{}) *redis.IntCmd {
-	return r.client.LPushX(r.k(key), value)
+	return r.cmd().LPushX(r.k(key), value)
 }
 func (r *Client) LRange(key string, start, stop int64) *redis.StringSliceCmd {
-	return r.client.LRange(r.k(key), start, stop)
+	return r.cmd().LRange(r.k(key), start, stop)
 }
 func (r *Client) LRem(key string, count int64, value interface{}) *redis.IntCmd {
-	return r.client.LRem(r.k(key), count, value)
+	return r.cmd().LRem(r.k(key), count, value)
 }
 func (r *Client) LSet(key string, index int64, value interface{}) *redis.StatusCmd {
-	return r.client.LSet(r.k(key), index, value)
+	return r.cmd().LSet(r.k(key), index, value)
 }
 func (r *Client) LTrim(key string, start, stop int64) *redis.StatusCmd {
-	return r.client.LTrim(r.k(key), start, stop)
+	return r.cmd().LTrim(r.k(key), start, stop)
 }
 func (r *Client) RPop(key string) *redis.StringCmd {
-	return r.client.RPop(r.k(key))
+	return r.cmd().RPop(r.k(key))
 }
 func (r *Client) RPopLPush(source, destination string) *redis.StringCmd {
-	return r.client.RPopLPush(r.k(source), r.k(destination))
+	source, destination = r.k(source), r.k(destination)
+	if err := r.checkSlots(source, destination); err != nil {
+		return redis.NewStringResult("", err)
+	}
+	return r.cmd().RPopLPush(source, destination)
 }
 func (r *Client) RPush(key string, values ...interface{}) *redis.IntCmd {
-	return r.client.RPush(r.k(key), values...)
+	return r.cmd().RPush(r.k(key), values...)
 }
 func (r *Client) RPushX(key string, value interface{}) *redis.IntCmd {
-	return r.client.RPushX(r.k(key), value)
+	return r.cmd().RPushX(r.k(key), value)
 }
 
 // -------------- Setter
 
 // Set function
 func (r *Client) Set(key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
-	return r.client.Set(r.k(key), value, expiration)
+	return r.cmd().Set(r.k(key), value, expiration)
 }
 func (r *Client) Append(key, value string) *redis.IntCmd {
-	return r.client.Append(r.k(key), value)
+	return r.cmd().Append(r.k(key), value)
 }
 func (r *Client) Del(keys ...string) *redis.IntCmd {
-	return r.client.Del(r.ks(keys...)...)
+	keys = r.ks(keys...)
+	if err := r.checkSlots(keys...); err != nil {
+		return redis.NewIntResult(0, err)
+	}
+	return r.cmd().Del(keys...)
 }
 func (r *Client) Unlink(keys ...string) *redis.IntCmd {
-	return r.client.Unlink(r.ks(keys...)...)
+	keys = r.ks(keys...)
+	if err := r.checkSlots(keys...); err != nil {
+		return redis.NewIntResult(0, err)
+	}
+	return r.cmd().Unlink(keys...)
+}
+
+// SetNX sets the value only if the key does not exist
+func (r *Client) SetNX(key string, value interface{}, expiration time.Duration) *redis.BoolCmd {
+	return r.cmd().SetNX(r.k(key), value, expiration)
+}
+
+// SetXX sets the value only if the key exists
+func (r *Client) SetXX(key string, value interface{}, expiration time.Duration) *redis.BoolCmd {
+	return r.cmd().SetXX(r.k(key), value, expiration)
+}
+func (r *Client) SetRange(key string, offset int64, value string) *redis.IntCmd {
+	return r.cmd().SetRange(r.k(key), offset, value)
+}
+
+// MSet sets key/value pairs, .e.g. MSet("key1", "value1", "key2", "value2")
+func (r *Client) MSet(pairs ...interface{}) *redis.StatusCmd {
+	pairs, keys := r.kPairs(pairs)
+	if err := r.checkSlots(keys...); err != nil {
+		return redis.NewStatusResult("", err)
+	}
+	return r.cmd().MSet(pairs...)
+}
+
+// MSetNX sets key/value pairs only if none of the keys exists
+func (r *Client) MSetNX(pairs ...interface{}) *redis.BoolCmd {
+	pairs, keys := r.kPairs(pairs)
+	if err := r.checkSlots(keys...); err != nil {
+		return redis.NewBoolResult(false, err)
+	}
+	return r.cmd().MSetNX(pairs...)
+}
+
+// -------------- Bitmapper
+
+func (r *Client) SetBit(key string, offset int64, value int) *redis.IntCmd {
+	return r.cmd().SetBit(r.k(key), offset, value)
+}
+func (r *Client) BitCount(key string, bitCount *redis.BitCount) *redis.IntCmd {
+	return r.cmd().BitCount(r.k(key), bitCount)
+}
+func (r *Client) BitPos(key string, bit int64, pos ...int64) *redis.IntCmd {
+	return r.cmd().BitPos(r.k(key), bit, pos...)
+}
+func (r *Client) BitOpAnd(destKey string, keys ...string) *redis.IntCmd {
+	keys = r.ks(append([]string{destKey}, keys...)...)
+	if err := r.checkSlots(keys...); err != nil {
+		return redis.NewIntResult(0, err)
+	}
+	return r.cmd().BitOpAnd(keys[0], keys[1:]...)
+}
+func (r *Client) BitOpOr(destKey string, keys ...string) *redis.IntCmd {
+	keys = r.ks(append([]string{destKey}, keys...)...)
+	if err := r.checkSlots(keys...); err != nil {
+		return redis.NewIntResult(0, err)
+	}
+	return r.cmd().BitOpOr(keys[0], keys[1:]...)
+}
+func (r *Client) BitOpXor(destKey string, keys ...string) *redis.IntCmd {
+	keys = r.ks(append([]string{destKey}, keys...)...)
+	if err := r.checkSlots(keys...); err != nil {
+		return redis.NewIntResult(0, err)
+	}
+	return r.cmd().BitOpXor(keys[0], keys[1:]...)
+}
+func (r *Client) BitOpNot(destKey string, key string) *redis.IntCmd {
+	destKey, key = r.k(destKey), r.k(key)
+	if err := r.checkSlots(destKey, key); err != nil {
+		return redis.NewIntResult(0, err)
+	}
+	return r.cmd().BitOpNot(destKey, key)
 }
 
 // -------------- Settable
 
 func (r *Client) SAdd(key string, members ...interface{}) *redis.IntCmd {
-	return r.client.SAdd(r.k(key), members...)
+	return r.cmd().SAdd(r.k(key), members...)
 }
 func (r *Client) SCard(key string) *redis.IntCmd {
-	return r.client.SCard(r.k(key))
+	return r.cmd().SCard(r.k(key))
 }
 func (r *Client) SDiff(keys ...string) *redis.StringSliceCmd {
-	return r.client.SDiff(r.ks(keys...)...)
+	keys = r.ks(keys...)
+	if err := r.checkSlots(keys...); err != nil {
+		return redis.NewStringSliceResult(nil, err)
+	}
+	return r.cmd().SDiff(keys...)
 }
 func (r *Client) SDiffStore(destination string, keys ...string) *redis.IntCmd {
-	return r.client.SDiffStore(r.k(destination), r.ks(keys...)...)
+	keys = r.ks(append([]string{destination}, keys...)...)
+	if err := r.checkSlots(keys...); err != nil {
+		return redis.NewIntResult(0, err)
+	}
+	return r.cmd().SDiffStore(keys[0], keys[1:]...)
 }
 func (r *Client) SInter(keys ...string) *redis.StringSliceCmd {
-	return r.client.SInter(r.ks(keys...)...)
+	keys = r.ks(keys...)
+	if err := r.checkSlots(keys...); err != nil {
+		return redis.NewStringSliceResult(nil, err)
+	}
+	return r.cmd().SInter(keys...)
 }
 func (r *Client) SInterStore(destination string, keys ...string) *redis.IntCmd {
-	return r.client.SInterStore(r.k(destination), r.ks(keys...)...)
+	keys = r.ks(append([]string{destination}, keys...)...)
+	if err := r.checkSlots(keys...); err != nil {
+		return redis.NewIntResult(0, err)
+	}
+	return r.cmd().SInterStore(keys[0], keys[1:]...)
 }
 func (r *Client) SIsMember(key string, member interface{}) *redis.BoolCmd {
-	return r.client.SIsMember(r.k(key), member)
+	return r.cmd().SIsMember(r.k(key), member)
 }
 func (r *Client) SMembers(key string) *redis.StringSliceCmd {
-	return r.client.SMembers(r.k(key))
+	return r.cmd().SMembers(r.k(key))
 }
 func (r *Client) SMove(source, destination string, member interface{}) *redis.BoolCmd {
-	return r.client.SMove(r.k(source), r.k(destination), member)
+	source, destination = r.k(source), r.k(destination)
+	if err := r.checkSlots(source, destination); err != nil {
+		return redis.NewBoolResult(false, err)
+	}
+	return r.cmd().SMove(source, destination, member)
 }
 func (r *Client) SPop(key string) *redis.StringCmd {
-	return r.client.SPop(r.k(key))
+	return r.cmd().SPop(r.k(key))
 }
 func (r *Client) SPopN(key string, count int64) *redis.StringSliceCmd {
-	return r.client.SPopN(r.k(key), count)
+	return r.cmd().SPopN(r.k(key), count)
 }
 func (r *Client) SRandMember(key string) *redis.StringCmd {
-	return r.client.SRandMember(r.k(key))
+	return r.cmd().SRandMember(r.k(key))
 }
 func (r *Client) SRandMemberN(key string, count int64) *redis.StringSliceCmd {
-	return r.client.SRandMemberN(r.k(key), count)
+	return r.cmd().SRandMemberN(r.k(key), count)
 }
 func (r *Client) SRem(key string, members ...interface{}) *redis.IntCmd {
-	return r.client.SRem(r.k(key), members...)
+	return r.cmd().SRem(r.k(key), members...)
 }
 func (r *Client) SUnion(keys ...string) *redis.StringSliceCmd {
-	return r.client.SUnion(r.ks(keys...)...)
+	keys = r.ks(keys...)
+	if err := r.checkSlots(keys...); err != nil {
+		return redis.NewStringSliceResult(nil, err)
+	}
+	return r.cmd().SUnion(keys...)
 }
 func (r *Client) SUnionStore(destination string, keys ...string) *redis.IntCmd {
-	return r.client.SUnionStore(r.k(destination), r.ks(keys...)...)
+	keys = r.ks(append([]string{destination}, keys...)...)
+	if err := r.checkSlots(keys...); err != nil {
+		return redis.NewIntResult(0, err)
+	}
+	return r.cmd().SUnionStore(keys[0], keys[1:]...)
 }
 
 // -------------- SortedSettable
 
 func (r *Client) ZAdd(key string, members ...redis.Z) *redis.IntCmd {
-	return r.client.ZAdd(r.k(key), members...)
+	return r.cmd().ZAdd(r.k(key), members...)
 }
 func (r *Client) ZAddNX(key string, members ...redis.Z) *redis.IntCmd {
-	return r.client.ZAddNX(r.k(key), members...)
+	return r.cmd().ZAddNX(r.k(key), members...)
 }
 func (r *Client) ZAddXX(key string, members ...redis.Z) *redis.IntCmd {
-	return r.client.ZAddXX(r.k(key), members...)
+	return r.cmd().ZAddXX(r.k(key), members...)
 }
 func (r *Client) ZAddCh(key string, members ...redis.Z) *redis.IntCmd {
-	return r.client.ZAddCh(r.k(key), members...)
+	return r.cmd().ZAddCh(r.k(key), members...)
 }
 func (r *Client) ZAddNXCh(key string, members ...redis.Z) *redis.IntCmd {
-	return r.client.ZAddNXCh(r.k(key), members...)
+	return r.cmd().ZAddNXCh(r.k(key), members...)
 }
 func (r *Client) ZAddXXCh(key string, members ...redis.Z) *redis.IntCmd {
-	return r.client.ZAddXXCh(r.k(key), members...)
+	return r.cmd().ZAddXXCh(r.k(key), members...)
 }
 func (r *Client) ZIncr(key string, member redis.Z) *redis.FloatCmd {
-	return r.client.ZIncr(r.k(key), member)
+	return r.cmd().ZIncr(r.k(key), member)
 }
 func (r *Client) ZIncrNX(key string, member redis.Z) *redis.FloatCmd {
-	return r.client.ZIncrNX(r.k(key), member)
+	return r.cmd().ZIncrNX(r.k(key), member)
 }
 func (r *Client) ZIncrXX(key string, member redis.Z) *redis.FloatCmd {
-	return r.client.ZIncrXX(r.k(key), member)
+	return r.cmd().ZIncrXX(r.k(key), member)
 }
 func (r *Client) ZCard(key string) *redis.IntCmd {
-	return r.client.ZCard(r.k(key))
+	return r.cmd().ZCard(r.k(key))
 }
 func (r *Client) ZCount(key, min, max string) *redis.IntCmd {
-	return r.client.ZCount(r.k(key), min, max)
+	return r.cmd().ZCount(r.k(key), min, max)
 }
 func (r *Client) ZIncrBy(key string, increment float64, member string) *redis.FloatCmd {
-	return r.client.ZIncrBy(r.k(key), increment, member)
+	return r.cmd().ZIncrBy(r.k(key), increment, member)
 }
 func (r *Client) ZInterStore(key string, store redis.ZStore, keys ...string) *redis.IntCmd {
-	return r.client.ZInterStore(r.k(key), store, r.ks(keys...)...)
+	keys = r.ks(append([]string{key}, keys...)...)
+	if err := r.checkSlots(keys...); err != nil {
+		return redis.NewIntResult(0, err)
+	}
+	return r.cmd().ZInterStore(keys[0], store, keys[1:]...)
 }
 func (r *Client) ZRange(key string, start, stop int64) *redis.StringSliceCmd {
-	return r.client.ZRange(r.k(key), start, stop)
+	return r.cmd().ZRange(r.k(key), start, stop)
 }
 func (r *Client) ZRangeWithScores(key string, start, stop int64) *redis.ZSliceCmd {
-	return r.client.ZRangeWithScores(r.k(key), start, stop)
+	return r.cmd().ZRangeWithScores(r.k(key), start, stop)
 }
 func (r *Client) ZRangeByScore(key string, opt redis.ZRangeBy) *redis.StringSliceCmd {
-	return r.client.ZRangeByScore(r.k(key), opt)
+	return r.cmd().ZRangeByScore(r.k(key), opt)
 }
 func (r *Client) ZRangeByLex(key string, opt redis.ZRangeBy) *redis.StringSliceCmd {
-	return r.client.ZRangeByLex(r.k(key), opt)
+	return r.cmd().ZRangeByLex(r.k(key), opt)
 }
 func (r *Client) ZRangeByScoreWithScores(key string, opt redis.ZRangeBy) *redis.ZSliceCmd {
-	return r.client.ZRangeByScoreWithScores(r.k(key), opt)
+	return r.cmd().ZRangeByScoreWithScores(r.k(key), opt)
 }
 func (r *Client) ZRank(key, member string) *redis.IntCmd {
-	return r.client.ZRank(r.k(key), member)
+	return r.cmd().ZRank(r.k(key), member)
 }
 func (r *Client) ZRem(key string, members ...interface{}) *redis.IntCmd {
-	return r.client.ZRem(r.k(key), members...)
+	return r.cmd().ZRem(r.k(key), members...)
 }
 func (r *Client) ZRemRangeByRank(key string, start, stop int64) *redis.IntCmd {
-	return r.client.ZRemRangeByRank(r.k(key), start, stop)
+	return r.cmd().ZRemRangeByRank(r.k(key), start, stop)
 }
 func (r *Client) ZRemRangeByScore(key, min, max string) *redis.IntCmd {
-	return r.client.ZRemRangeByScore(r.k(key), min, max)
+	return r.cmd().ZRemRangeByScore(r.k(key), min, max)
 }
 func (r *Client) ZRemRangeByLex(key, min, max string) *redis.IntCmd {
-	return r.client.ZRemRangeByLex(r.k(key), min, max)
+	return r.cmd().ZRemRangeByLex(r.k(key), min, max)
 }
 func (r *Client) ZRevRange(key string, start, stop int64) *redis.StringSliceCmd {
-	return r.client.ZRevRange(r.k(key), start, stop)
+	return r.cmd().ZRevRange(r.k(key), start, stop)
 }
 func (r *Client) ZRevRangeWithScores(key string, start, stop int64) *redis.ZSliceCmd {
-	return r.client.ZRevRangeWithScores(r.k(key), start, stop)
+	return r.cmd().ZRevRangeWithScores(r.k(key), start, stop)
 }
 func (r *Client) ZRevRangeByScore(key string, opt redis.ZRangeBy) *redis.StringSliceCmd {
-	return r.client.ZRevRangeByScore(r.k(key), opt)
+	return r.cmd().ZRevRangeByScore(r.k(key), opt)
 }
 func (r *Client) ZRevRangeByLex(key string, opt redis.ZRangeBy) *redis.StringSliceCmd {
-	return r.client.ZRevRangeByLex(r.k(key), opt)
+	return r.cmd().ZRevRangeByLex(r.k(key), opt)
 }
 func (r *Client) ZRevRangeByScoreWithScores(key string, opt redis.ZRangeBy) *redis.ZSliceCmd {
-	return r.client.ZRevRangeByScoreWithScores(r.k(key), opt)
+	return r.cmd().ZRevRangeByScoreWithScores(r.k(key), opt)
 }
 func (r *Client) ZRevRank(key, member string) *redis.IntCmd {
-	return r.client.ZRevRank(r.k(key), member)
+	return r.cmd().ZRevRank(r.k(key), member)
 }
 func (r *Client) ZScore(key, member string) *redis.FloatCmd {
-	return r.client.ZScore(r.k(key), member)
+	return r.cmd().ZScore(r.k(key), member)
 }
 func (r *Client) ZUnionStore(dest string, store redis.ZStore, keys ...string) *redis.IntCmd {
-	return r.client.ZUnionStore(r.k(dest), store, r.ks(keys...)...)
+	keys = r.ks(append([]string{dest}, keys...)...)
+	if err := r.checkSlots(keys...); err != nil {
+		return redis.NewIntResult(0, err)
+	}
+	return r.cmd().ZUnionStore(keys[0], store, keys[1:]...)
 }
 
 // -------------- BlockedSettable
 
 func (r *Client) BLPop(timeout time.Duration, keys ...string) *redis.StringSliceCmd {
-	return r.client.BLPop(timeout, r.ks(keys...)...)
+	keys = r.ks(keys...)
+	if err := r.checkSlots(keys...); err != nil {
+		return redis.NewStringSliceResult(nil, err)
+	}
+	return r.cmd().BLPop(timeout, keys...)
 }
 func (r *Client) BRPop(timeout time.Duration, keys ...string) *redis.StringSliceCmd {
-	return r.client.BRPop(timeout, r.ks(keys...)...)
+	keys = r.ks(keys...)
+	if err := r.checkSlots(keys...); err != nil {
+		return redis.NewStringSliceResult(nil, err)
+	}
+	return r.cmd().BRPop(timeout, keys...)
 }
 func (r *Client) BRPopLPush(source, destination string, timeout time.Duration) *redis.StringCmd {
-	return r.client.BRPopLPush(r.k(source), r.k(destination), timeout)
+	source, destination = r.k(source), r.k(destination)
+	if err := r.checkSlots(source, destination); err != nil {
+		return redis.NewStringResult("", err)
+	}
+	return r.cmd().BRPopLPush(source, destination, timeout)
+}
+
+// -------------- HyperLogLogger
+
+func (r *Client) PFAdd(key string, els ...interface{}) *redis.IntCmd {
+	return r.cmd().PFAdd(r.k(key), els...)
+}
+func (r *Client) PFCount(keys ...string) *redis.IntCmd {
+	keys = r.ks(keys...)
+	if err := r.checkSlots(keys...); err != nil {
+		return redis.NewIntResult(0, err)
+	}
+	return r.cmd().PFCount(keys...)
+}
+func (r *Client) PFMerge(dest string, keys ...string) *redis.StatusCmd {
+	keys = r.ks(append([]string{dest}, keys...)...)
+	if err := r.checkSlots(keys...); err != nil {
+		return redis.NewStatusResult("", err)
+	}
+	return r.cmd().PFMerge(keys[0], keys[1:]...)
+}
+
+// -------------- Geospatial
+
+func (r *Client) GeoAdd(key string, geoLocation ...*redis.GeoLocation) *redis.IntCmd {
+	return r.cmd().GeoAdd(r.k(key), geoLocation...)
+}
+
+// GeoRadius georadius command, the Store and StoreDist keys of query are prefixed
+func (r *Client) GeoRadius(key string, longitude, latitude float64, query *redis.GeoRadiusQuery) *redis.GeoLocationCmd {
+	key, query, err := r.kGeoQuery(key, query)
+	if err != nil {
+		return redis.NewGeoLocationCmdResult(nil, err)
+	}
+	return r.cmd().GeoRadius(key, longitude, latitude, query)
+}
+func (r *Client) GeoRadiusRO(key string, longitude, latitude float64, query *redis.GeoRadiusQuery) *redis.GeoLocationCmd {
+	key, query, err := r.kGeoQuery(key, query)
+	if err != nil {
+		return redis.NewGeoLocationCmdResult(nil, err)
+	}
+	return r.cmd().GeoRadiusRO(key, longitude, latitude, query)
+}
+
+// GeoRadiusByMember georadiusbymember command, the Store and StoreDist keys of query are prefixed
+func (r *Client) GeoRadiusByMember(key, member string, query *redis.GeoRadiusQuery) *redis.GeoLocationCmd {
+	key, query, err := r.kGeoQuery(key, query)
+	if err != nil {
+		return redis.NewGeoLocationCmdResult(nil, err)
+	}
+	return r.cmd().GeoRadiusByMember(key, member, query)
+}
+func (r *Client) GeoRadiusByMemberRO(key, member string, query *redis.GeoRadiusQuery) *redis.GeoLocationCmd {
+	key, query, err := r.kGeoQuery(key, query)
+	if err != nil {
+		return redis.NewGeoLocationCmdResult(nil, err)
+	}
+	return r.cmd().GeoRadiusByMemberRO(key, member, query)
+}
+func (r *Client) GeoDist(key string, member1, member2, unit string) *redis.FloatCmd {
+	return r.cmd().GeoDist(r.k(key), member1, member2, unit)
+}
+func (r *Client) GeoHash(key string, members ...string) *redis.StringSliceCmd {
+	return r.cmd().GeoHash(r.k(key), members...)
+}
+func (r *Client) GeoPos(key string, members ...string) *redis.GeoPosCmd {
+	return r.cmd().GeoPos(r.k(key), members...)
+}
+
+// kGeoQuery prefixes key and returns a copy of query with prefixed
+// Store and StoreDist keys, which must be in the slot of key
+func (r *Client) kGeoQuery(key string, query *redis.GeoRadiusQuery) (string, *redis.GeoRadiusQuery, error) {
+	key = r.k(key)
+	if query == nil || (query.Store == "" && query.StoreDist == "") {
+		return key, query, nil
+	}
+	q := *query
+	keys := []string{key}
+	if q.Store != "" {
+		q.Store = r.k(q.Store)
+		keys = append(keys, q.Store)
+	}
+	if q.StoreDist != "" {
+		q.StoreDist = r.k(q.StoreDist)
+		keys = append(keys, q.StoreDist)
+	}
+	return key, &q, r.checkSlots(keys...)
+}
+
+// -------------- Scripter
+
+// Eval eval command, keys are prefixed and passed as KEYS
+func (r *Client) Eval(script string, keys []string, args ...interface{}) *redis.Cmd {
+	keys = r.ks(keys...)
+	if err := r.checkSlots(keys...); err != nil {
+		return redis.NewCmdResult(nil, err)
+	}
+	return r.cmd().Eval(script, keys, args...)
+}
+
+// EvalSha evalsha command, keys are prefixed and passed as KEYS
+func (r *Client) EvalSha(sha1 string, keys []string, args ...interface{}) *redis.Cmd {
+	keys = r.ks(keys...)
+	if err := r.checkSlots(keys...); err != nil {
+		return redis.NewCmdResult(nil, err)
+	}
+	return r.cmd().EvalSha(sha1, keys, args...)
+}
+func (r *Client) ScriptExists(hashes ...string) *redis.BoolSliceCmd {
+	return r.cmd().ScriptExists(hashes...)
+}
+func (r *Client) ScriptLoad(script string) *redis.StringCmd {
+	return r.cmd().ScriptLoad(script)
 }
 
 // -------------- Scanner
 
 func (r *Client) Type(key string) *redis.StatusCmd {
-	return r.client.Type(r.k(key))
+	return r.cmd().Type(r.k(key))
 }
+
+// Scan scans the node of the underlying client only and returns prefixed
+// keys, use ScanIterator to scan every node without prefix
 func (r *Client) Scan(cursor uint64, match string, count int64) *redis.ScanCmd {
-	return r.client.Scan(cursor, r.k(match), count)
+	return r.cmd().Scan(cursor, r.k(match), count)
 }
 func (r *Client) SScan(key string, cursor uint64, match string, count int64) *redis.ScanCmd {
-	return r.client.SScan(r.k(key), cursor, match, count)
+	return r.cmd().SScan(r.k(key), cursor, match, count)
 }
 func (r *Client) ZScan(key string, cursor uint64, match string, count int64) *redis.ScanCmd {
-	return r.client.ZScan(r.k(key), cursor, match, count)
+	return r.cmd().ZScan(r.k(key), cursor, match, count)
 }
 func (r *Client) HScan(key string, cursor uint64, match string, count int64) *redis.ScanCmd {
-	return r.client.HScan(r.k(key), cursor, match, count)
+	return r.cmd().HScan(r.k(key), cursor, match, count)
 }
 
 // -------------- Publisher
 
 func (r *Client) Publish(channel string, message interface{}) *redis.IntCmd {
-	return r.client.Publish(r.k(channel), message)
+	return r.cmd().Publish(r.k(channel), message)
 }
 func (r *Client) Subscribe(channels ...string) *redis.PubSub {
-	return r.client.Subscribe(r.ks(channels...)...)
+	return r.cmd().Subscribe(r.ks(channels...)...)
 }
 
-// Pipeline get Pipeliner of r.client
+// Pipeline get Pipeliner of the underlying client, keys are not prefixed,
+// use PrefixedPipeline to apply the KeyPrefix
 func (r *Client) Pipeline() redis.Pipeliner {
-	return r.client.Pipeline()
+	return r.cmd().Pipeline()
+}
+
+// TxPipeline get Pipeliner wrapped in MULTI/EXEC of the underlying client,
+// keys are not prefixed, use TxPrefixedPipeline to apply the KeyPrefix
+func (r *Client) TxPipeline() redis.Pipeliner {
+	return r.cmd().TxPipeline()
 }
 
 // ErrNotImplemented not implemented error
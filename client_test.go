@@ -1,14 +1,509 @@
 package redisClient_test
 
 import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
 
 	redis "github.com/alauda/go-redis-client"
+	goredis "github.com/go-redis/redis"
 )
 
+// fakeServer answers the commands of redis clients with reply and records them
+type fakeServer struct {
+	mu       sync.Mutex
+	listener net.Listener
+	commands []string
+}
+
+func newFakeServer(t *testing.T, network, addr string, reply func(args []string) string) *fakeServer {
+	listener, err := net.Listen(network, addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeServer{listener: listener}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn, reply)
+		}
+	}()
+	return s
+}
+
+func (s *fakeServer) serve(conn net.Conn, reply func(args []string) string) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	for {
+		var n int
+		if _, err := fmt.Fscanf(r, "*%d\r\n", &n); err != nil {
+			return
+		}
+		args := make([]string, n)
+		for i := range args {
+			var size int
+			if _, err := fmt.Fscanf(r, "$%d\r\n", &size); err != nil {
+				return
+			}
+			buf := make([]byte, size+2)
+			if _, err := io.ReadFull(r, buf); err != nil {
+				return
+			}
+			args[i] = string(buf[:size])
+		}
+		s.mu.Lock()
+		s.commands = append(s.commands, strings.ToLower(strings.Join(args, " ")))
+		s.mu.Unlock()
+		if _, err := conn.Write([]byte(reply(args))); err != nil {
+			return
+		}
+	}
+}
+
+// received reports whether a command starting with prefix was received
+func (s *fakeServer) received(prefix string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, cmd := range s.commands {
+		if strings.HasPrefix(cmd, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *fakeServer) Addr() string {
+	return s.listener.Addr().String()
+}
+
+func (s *fakeServer) Close() {
+	s.listener.Close()
+}
+
+// newFakeCluster starts two fake masters serving half of the slots each, the
+// commands other than CLUSTER INFO, CLUSTER SLOTS and COMMAND are answered by reply
+func newFakeCluster(t *testing.T, reply func(node int, args []string) string) []*fakeServer {
+	// the cluster client routes the commands by their first key given by COMMAND
+	keyPos := map[string]int{"cluster": 0, "eval": 3, "get": 1, "set": 1, "mget": 1, "mset": 1, "del": 1, "exists": 1}
+	commands := fmt.Sprintf("*%d\r\n", len(keyPos))
+	for name, pos := range keyPos {
+		commands += fmt.Sprintf("*6\r\n$%d\r\n%s\r\n:-2\r\n*0\r\n:%d\r\n:-1\r\n:1\r\n", len(name), name, pos)
+	}
+	var addrs []string
+	servers := make([]*fakeServer, 2)
+	for i := range servers {
+		i := i
+		servers[i] = newFakeServer(t, "tcp", "127.0.0.1:0", func(args []string) string {
+			switch strings.ToLower(strings.Join(args, " ")) {
+			case "cluster info":
+				return "$16\r\ncluster_state:ok\r\n"
+			case "command":
+				return commands
+			case "cluster slots":
+				reply := "*2\r\n"
+				for j, addr := range addrs {
+					host, port, _ := net.SplitHostPort(addr)
+					reply += fmt.Sprintf("*3\r\n:%d\r\n:%d\r\n*2\r\n$%d\r\n%s\r\n:%s\r\n",
+						j*8192, j*8192+8191, len(host), host, port)
+				}
+				return reply
+			}
+			return reply(i, args)
+		})
+		addrs = append(addrs, servers[i].Addr())
+	}
+	return servers
+}
+
 func TestConstructor(t *testing.T) {
 	redis.NewClient(redis.Options{
 		Type:  redis.ClientNormal,
 		Hosts: []string{"127.0.0.1:3698"},
 	})
 }
+
+func TestAutoTypeFallback(t *testing.T) {
+	client := redis.NewClient(redis.Options{
+		Type:  redis.ClientAuto,
+		Hosts: []string{"127.0.0.1:3698"},
+	})
+	if client.ClientType() != redis.ClientNormal {
+		t.Error("unreachable hosts should fall back to normal, got:", client.ClientType())
+	}
+
+	dir, err := ioutil.TempDir("", "redis-socket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "redis.sock")
+	server := newFakeServer(t, "unix", socket, func(args []string) string {
+		if strings.ToLower(args[0]) == "role" {
+			return "*3\r\n$6\r\nmaster\r\n:0\r\n*0\r\n"
+		}
+		return "-ERR unknown command\r\n"
+	})
+	defer server.Close()
+	client = redis.NewClient(redis.Options{
+		Type:    redis.ClientAuto,
+		Network: "unix",
+		Hosts:   []string{socket},
+	})
+	defer client.Close()
+	if !server.received("role") {
+		t.Error("the unix socket should be probed")
+	}
+	if client.ClientType() != redis.ClientNormal {
+		t.Error("a master without cluster should be normal, got:", client.ClientType())
+	}
+
+	// ROLE denied by ACLs or unknown to old servers
+	tests := []struct {
+		replies map[string]string
+		want    redis.ClientType
+	}{
+		{map[string]string{"cluster info": "$16\r\ncluster_state:ok\r\n"}, redis.ClientCluster},
+		{map[string]string{"sentinel masters": "*1\r\n*2\r\n$4\r\nname\r\n$8\r\nmymaster\r\n"}, redis.ClientSentinel},
+		{map[string]string{}, redis.ClientNormal},
+	}
+	for _, test := range tests {
+		server := newFakeServer(t, "tcp", "127.0.0.1:0", func(args []string) string {
+			if strings.ToLower(args[0]) == "role" {
+				return "-NOPERM this user has no permissions to run the 'role' command\r\n"
+			}
+			if reply, ok := test.replies[strings.ToLower(strings.Join(args, " "))]; ok {
+				return reply
+			}
+			return "-ERR unknown command\r\n"
+		})
+		client := redis.NewClient(redis.Options{
+			Type:               redis.ClientAuto,
+			Hosts:              []string{server.Addr()},
+			SkipFullCoverCheck: true,
+		})
+		if client.ClientType() != test.want {
+			t.Errorf("got type %s, want %s", client.ClientType(), test.want)
+		}
+		client.Close()
+		server.Close()
+	}
+}
+
+func TestUncoveredSlots(t *testing.T) {
+	slots := []goredis.ClusterSlot{
+		{Start: 0, End: 5460, Nodes: []goredis.ClusterNode{{Addr: "127.0.0.1:7000"}}},
+		{Start: 5461, End: 10922},
+		{Start: 10923, End: 16000, Nodes: []goredis.ClusterNode{{Addr: "127.0.0.1:7002"}}},
+	}
+	exp := []redis.SlotRange{{Start: 5461, End: 10922}, {Start: 16001, End: 16383}}
+	if res := redis.UncoveredSlots(slots); !reflect.DeepEqual(exp, res) {
+		t.Error("bad result:", res)
+	}
+}
+
+func TestPrefixedPipeline(t *testing.T) {
+	client := redis.NewClient(redis.Options{
+		Type:      redis.ClientNormal,
+		Hosts:     []string{"127.0.0.1:3698"},
+		KeyPrefix: "app:",
+	})
+	defer client.Close()
+	pipe := client.PrefixedPipeline()
+	defer pipe.Close()
+	exp := []interface{}{"rpoplpush", "app:src", "app:dst"}
+	if res := pipe.RPopLPush("src", "dst").Args(); !reflect.DeepEqual(exp, res) {
+		t.Error("bad args:", res)
+	}
+	query := &goredis.GeoRadiusQuery{Radius: 1, Store: "near"}
+	args := pipe.GeoRadius("shops", 2.35, 48.85, query).Args()
+	if res := args[len(args)-1]; res != "app:near" || query.Store != "near" {
+		t.Error("bad store key:", res, query.Store)
+	}
+	exp = []interface{}{"sort", "app:ids", "by", "app:weight_*", "get", "#", "get", "app:name_*"}
+	if res := pipe.Sort("ids", goredis.Sort{By: "weight_*", Get: []string{"#", "name_*"}}).Args(); !reflect.DeepEqual(exp, res) {
+		t.Error("bad args:", res)
+	}
+	exp = []interface{}{"mset", "app:a", "1", "app:b", 2}
+	if res := pipe.MSet("a", "1", "b", 2).Args(); !reflect.DeepEqual(exp, res) {
+		t.Error("bad args:", res)
+	}
+
+	ring := redis.NewClient(redis.Options{
+		Type:   redis.ClientRing,
+		Shards: map[string]string{"shard1": "127.0.0.1:3698"},
+	})
+	defer ring.Close()
+	_, err := ring.TxPipelined(func(pipe *redis.PrefixedPipeline) error {
+		pipe.Incr("counter")
+		return nil
+	})
+	if err != redis.ErrNotImplemented {
+		t.Error("ring transactions should not be implemented, got:", err)
+	}
+}
+
+func TestPrefixedPipelineKeys(t *testing.T) {
+	server := newFakeServer(t, "tcp", "127.0.0.1:0", func(args []string) string {
+		if strings.ToLower(args[0]) != "eval" {
+			return "-ERR unknown command\r\n"
+		}
+		// the scripts strip the prefix ARGV[2] of KEYS ARGV[1] and the prefix ARGV[1] of RANDOMKEY
+		if strings.Contains(args[1], "'KEYS'") && args[3] == "app:user*" && args[4] == "app:" {
+			return "*2\r\n$5\r\nuser1\r\n$5\r\nuser2\r\n"
+		}
+		if strings.Contains(args[1], "'RANDOMKEY'") && args[3] == "app:" {
+			return "$5\r\nuser1\r\n"
+		}
+		return "-ERR bad script\r\n"
+	})
+	defer server.Close()
+	client := redis.NewClient(redis.Options{
+		Type:      redis.ClientNormal,
+		Hosts:     []string{server.Addr()},
+		KeyPrefix: "app:",
+	})
+	defer client.Close()
+
+	var keys *goredis.StringSliceCmd
+	var key *goredis.StringCmd
+	if _, err := client.Pipelined(func(pipe *redis.PrefixedPipeline) error {
+		keys = pipe.Keys("user*")
+		key = pipe.RandomKey()
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if res := keys.Val(); !reflect.DeepEqual(res, []string{"user1", "user2"}) {
+		t.Error("bad keys:", res)
+	}
+	if res := key.Val(); res != "user1" {
+		t.Error("bad random key:", res)
+	}
+}
+
+func TestNamespace(t *testing.T) {
+	client := redis.NewClient(redis.Options{
+		Type:      redis.ClientNormal,
+		Hosts:     []string{"127.0.0.1:3698"},
+		KeyPrefix: "app:",
+	})
+	defer client.Close()
+	orders := client.Namespace("orders:")
+	if res := orders.Prefix("1"); res != "app:orders:1" {
+		t.Error("bad prefix:", res)
+	}
+	if res := orders.HashTagNamespace("user1").Prefix("cart"); res != "app:orders:{user1}cart" {
+		t.Error("bad hash tag prefix:", res)
+	}
+	if orders.GetClient() != client.GetClient() {
+		t.Error("namespace should share the connection of its parent")
+	}
+}
+
+func TestHashTag(t *testing.T) {
+	client := redis.NewClient(redis.Options{
+		Type:               redis.ClientCluster,
+		Hosts:              []string{"127.0.0.1:3698"},
+		KeyPrefix:          "app:",
+		HashTagPattern:     `^tenant:\d+`,
+		SkipFullCoverCheck: true,
+	})
+	defer client.Close()
+	for key, exp := range map[string]string{
+		"tenant:1:orders": "app:{tenant:1}:orders",
+		"{user1}:cart":    "app:{user1}:cart",
+		"global":          "app:global",
+	} {
+		if res := client.Prefix(key); res != exp {
+			t.Errorf("Prefix(%q) = %q, want %q", key, res, exp)
+		}
+	}
+	err := client.SUnionStore("all", "tenant:1:a", "tenant:2:b").Err()
+	if _, ok := err.(*redis.CrossSlotError); !ok {
+		t.Error("keys of different slots should be rejected, got:", err)
+	}
+	err = client.BitOpAnd("tenant:1:all", "tenant:1:a", "tenant:1:b").Err()
+	if _, ok := err.(*redis.CrossSlotError); ok {
+		t.Error("keys of one hash tag should not be rejected, got:", err)
+	}
+
+	var exists, del *goredis.IntCmd
+	_, err = client.TxPipelined(func(pipe *redis.PrefixedPipeline) error {
+		del = pipe.Del("tenant:1:a", "tenant:1:b")
+		exists = pipe.Exists("tenant:1:a", "tenant:2:b")
+		pipe.MSet("tenant:1:a", 1, "tenant:2:b", 2)
+		return nil
+	})
+	if _, ok := err.(*redis.CrossSlotError); !ok {
+		t.Error("a pipeline queuing keys of different slots should fail, got:", err)
+	}
+	if _, ok := exists.Err().(*redis.CrossSlotError); !ok {
+		t.Error("the command of keys of different slots should fail, got:", exists.Err())
+	}
+	if _, ok := del.Err().(*redis.CrossSlotError); ok {
+		t.Error("keys of one hash tag should be queued, got:", del.Err())
+	}
+}
+
+func TestBatchUnreachable(t *testing.T) {
+	client := redis.NewClient(redis.Options{
+		Type:  redis.ClientRing,
+		Hosts: []string{"127.0.0.1:3698"},
+	})
+	defer client.Close()
+	results, err := client.BatchGet("a", "b", "c")
+	if err == nil || len(results) != 3 {
+		t.Fatal("unreachable ring should fail every key, got:", results, err)
+	}
+	for i, key := range []string{"a", "b", "c"} {
+		if results[i].Key != key || results[i].Err == nil {
+			t.Errorf("result %d = %+v, want key %s with an error", i, results[i], key)
+		}
+	}
+}
+
+func TestLoadScripts(t *testing.T) {
+	dir, err := ioutil.TempDir("", "redis-scripts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ioutil.WriteFile(filepath.Join(dir, "one.lua"), []byte("return 1"), 0600)
+	ioutil.WriteFile(filepath.Join(dir, "README.md"), []byte("not a script"), 0600)
+
+	scripts, err := redis.LoadScripts(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scripts) != 1 || scripts["one"] == nil {
+		t.Fatal("bad scripts:", scripts)
+	}
+	if res := scripts["one"].Hash(); res != "e0e1f9fabfc9d4800c877a703b823ac0578ff8db" {
+		t.Error("bad hash:", res)
+	}
+}
+
+func TestWatchRing(t *testing.T) {
+	ring := redis.NewClient(redis.Options{
+		Type:   redis.ClientRing,
+		Shards: map[string]string{"shard1": "127.0.0.1:3698"},
+	})
+	defer ring.Close()
+	err := ring.Transaction(context.Background(), func(tx *redis.Tx) error {
+		return nil
+	}, []string{"counter"}, 3)
+	if err != redis.ErrNotImplemented {
+		t.Error("ring transactions should not be implemented, got:", err)
+	}
+}
+
+func TestParseInfo(t *testing.T) {
+	info := redis.ParseInfo("# Memory\r\nused_memory:1024\r\nmaxmemory_policy:allkeys-lru\r\n\r\n# Replication\r\nrole:master\r\nconnected_slaves:1\r\n\r\n# Keyspace\r\ndb0:keys=3,expires=1,avg_ttl=500\r\n")
+	if info.Memory.UsedMemory != 1024 || info.Memory.MaxMemoryPolicy != "allkeys-lru" {
+		t.Error("bad memory:", info.Memory)
+	}
+	if info.Replication.Role != "master" || info.Replication.ConnectedSlaves != 1 {
+		t.Error("bad replication:", info.Replication)
+	}
+	if res := info.Keyspace[0]; res.Keys != 3 || res.Expires != 1 || res.AvgTTL != 500 {
+		t.Error("bad keyspace:", res)
+	}
+	if res := info.Sections["memory"]["used_memory"]; res != "1024" {
+		t.Error("bad section:", res)
+	}
+}
+
+func TestClusterTopology(t *testing.T) {
+	topology, err := redis.ParseClusterNodes("" +
+		"07c37dfeb235213a872192d90877d0cd55635b91 127.0.0.1:30004@31004 slave e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca 0 1426238317239 4 connected\n" +
+		"67ed2db8d677e59ec4a4cefb06858cf2a1a89fa1 127.0.0.1:30002@31002 master - 0 1426238316232 2 connected 5461-10922\n" +
+		"292f8b365bb7edb5e285caf0b7e6ddc7265d2f4f 127.0.0.1:30003@31003 master,fail - 0 1426238318243 3 disconnected 10923-16383\n" +
+		"e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca 127.0.0.1:30001@31001 myself,master - 0 0 1 connected 0-5460 [5461-<-67ed2db8d677e59ec4a4cefb06858cf2a1a89fa1]\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(topology.Masters) != 3 || topology.Masters[0].Addr != "127.0.0.1:30001" {
+		t.Fatal("bad masters:", topology.Masters)
+	}
+	if res := topology.Masters[0].Replicas; len(res) != 1 || res[0].Addr != "127.0.0.1:30004" {
+		t.Error("bad replicas:", res)
+	}
+	if res := topology.SlotOwner(5461); res == nil || res.Addr != "127.0.0.1:30002" {
+		t.Error("bad slot owner:", res)
+	}
+	if res := topology.Failing(); len(res) != 1 || res[0].Addr != "127.0.0.1:30003" {
+		t.Error("bad failing nodes:", res)
+	}
+	client := redis.NewClient(redis.Options{
+		Type:      redis.ClientNormal,
+		Hosts:     []string{"127.0.0.1:3698"},
+		KeyPrefix: "{foo}:",
+	})
+	defer client.Close()
+	if res := client.KeySlot("bar"); res != 12182 {
+		t.Error("bad key slot:", res)
+	}
+}
+
+func TestTLSNotSupported(t *testing.T) {
+	opts := redis.Options{
+		Type:      redis.ClientCluster,
+		Hosts:     []string{"127.0.0.1:3698"},
+		TLSConfig: &tls.Config{ServerName: "localhost"},
+	}
+	if _, err := redis.NewClientE(opts); err == nil || !strings.Contains(err.Error(), "TLS is not supported") {
+		t.Error("cluster client with TLS should be refused, got:", err)
+	}
+	client := redis.NewClient(opts)
+	defer client.Close()
+	if err := client.Ping().Err(); err == nil || !strings.Contains(err.Error(), "TLS is not supported") {
+		t.Error("commands should fail instead of being sent in plaintext, got:", err)
+	}
+	if err := client.CheckClusterCoverage(); err == nil || !strings.Contains(err.Error(), "TLS is not supported") {
+		t.Error("coverage check should return the TLS error, got:", err)
+	}
+	if _, err := client.ClusterTopology(); err == nil || !strings.Contains(err.Error(), "TLS is not supported") {
+		t.Error("topology should return the TLS error, got:", err)
+	}
+}
+
+func TestRandomKeyScanFallback(t *testing.T) {
+	keys := "*0\r\n"
+	server := newFakeServer(t, "tcp", "127.0.0.1:0", func(args []string) string {
+		switch strings.ToLower(args[0]) {
+		case "randomkey":
+			return "$5\r\nother\r\n"
+		case "scan":
+			return "*2\r\n$1\r\n0\r\n" + keys
+		}
+		return "+OK\r\n"
+	})
+	defer server.Close()
+	client := redis.NewClient(redis.Options{
+		Type:      redis.ClientNormal,
+		Hosts:     []string{server.Addr()},
+		KeyPrefix: "app:",
+	})
+	defer client.Close()
+
+	if err := client.RandomKey().Err(); err != goredis.Nil || !server.received("scan 0 match app:*") {
+		t.Error("RandomKey should scan the prefix and return redis.Nil, got:", err)
+	}
+	keys = "*1\r\n$5\r\napp:a\r\n"
+	if res, err := client.RandomKey().Result(); err != nil || res != "a" {
+		t.Error("RandomKey should return the key found by the scan, got:", res, err)
+	}
+}
@@ -1,6 +1,8 @@
 package redisClient
 
 import (
+	"crypto/tls"
+	"net"
 	"strings"
 	"time"
 
@@ -9,11 +11,22 @@ import (
 	"github.com/spf13/viper"
 )
 
+const (
+	// defaultPort is used when REDIS_PORT is absent
+	defaultPort = "6379"
+	// defaultSentinelPort is used when REDIS_SENTINEL_PORT is absent
+	defaultSentinelPort = "26379"
+)
+
 //addrStructure will create ADDR,For example string: "host:port"
 func addrStructure(redisPort []string, redisHosts []string) []string {
+	return addrStructureWithPort(redisPort, redisHosts, defaultPort)
+}
+
+// addrStructureWithPort will create ADDR using port as the default port
+func addrStructureWithPort(redisPort []string, redisHosts []string, port string) []string {
 	hosts := []string{}
 	if len(redisPort) != len(redisHosts) {
-		port := "6379"
 		if len(redisPort) == 0 {
 			logrus.Warnf("REDIS_PORT not exist, Use default port:%s", port)
 		} else {
@@ -36,54 +49,189 @@ func addrStructure(redisPort []string, redisHosts []string) []string {
 	return hosts
 }
 
-//customizedOption create options and config the Option
-func customizedOption(viper *viper.Viper, rwType RWType) *Options {
+// shardStructure will create ring shards,For example string: "name=host:port"
+// a shard without name is named after its address
+func shardStructure(redisShards []string) map[string]string {
+	if len(redisShards) == 0 {
+		return nil
+	}
+	shards := make(map[string]string, len(redisShards))
+	for _, shard := range redisShards {
+		shard = strings.TrimSpace(shard)
+		if shard == "" {
+			continue
+		}
+		name, addr := shard, shard
+		if index := strings.Index(shard, "="); index >= 0 {
+			name, addr = shard[:index], shard[index+1:]
+		}
+		if _, ok := shards[name]; ok {
+			logrus.Warnf("REDIS_RING_SHARDS shard %s is duplicated, Use last addr:%s", name, addr)
+		}
+		shards[name] = addr
+	}
+	return shards
+}
+
+// tlsStructure will create *tls.Config from REDIS_TLS_* params, nil when TLS is disabled
+func tlsStructure(p *paramReader, hosts []string) (*tls.Config, error) {
+	tlsOpts := TLSOptions{
+		CAFile:             p.getString("TLSConfig", "REDIS_TLS_CA_FILE"),
+		CertFile:           p.getString("TLSConfig", "REDIS_TLS_CERT_FILE"),
+		KeyFile:            p.getString("TLSConfig", "REDIS_TLS_KEY_FILE"),
+		ServerName:         p.getString("TLSConfig", "REDIS_TLS_SERVER_NAME"),
+		InsecureSkipVerify: p.getBool("TLSConfig", "REDIS_TLS_INSECURE_SKIP_VERIFY"),
+	}
+	if tlsOpts.IsZero() && !p.getBool("TLSConfig", "REDIS_TLS_ENABLED") {
+		return nil, nil
+	}
+	if tlsOpts.ServerName == "" && len(hosts) > 0 {
+		if host, _, err := net.SplitHostPort(hosts[0]); err == nil {
+			tlsOpts.ServerName = host
+			logrus.Warnf("REDIS_TLS_SERVER_NAME not exist, Use first host:%s", host)
+		}
+	}
+	return tlsOpts.Config()
+}
+
+// customizedOptionReport create options and the report of where each field
+// comes from, the report is nil when sources is nil
+func customizedOptionReport(viper *viper.Viper, rwType RWType, sources *util.Sources) (*Options, *ConfigReport, error) {
 
 	var opt = Options{}
 	letOldEnvSupportViper(viper, rwType)
-	hosts := addrStructure(viper.GetStringSlice(rwType.FmtSuffix("REDIS_PORT")),
-		viper.GetStringSlice(rwType.FmtSuffix("REDIS_HOST")))
-	opt.Type = ClientType(viper.GetString(rwType.FmtSuffix("REDIS_TYPE")))
+	p := &paramReader{viper: viper, rwType: rwType, sources: sources}
+	if redisURL := p.getString("URL", "REDIS_URL"); redisURL != "" {
+		return customizedOptionFromURL(p, redisURL)
+	}
+	opt.Network = p.getString("Network", "REDIS_NETWORK")
+	hosts := p.getStringSlice("Hosts", "REDIS_HOST")
+	if opt.Network != "unix" {
+		hosts = addrStructure(p.getStringSlice("Hosts", "REDIS_PORT"), hosts)
+	}
+	opt.Type = ClientType(p.getString("Type", "REDIS_TYPE"))
+	if !opt.Type.IsValid() {
+		logrus.Warnf("REDIS_TYPE %q is absent or unknown, Use type:%s", opt.Type, ClientAuto)
+		opt.Type = ClientAuto
+	}
 	opt.Hosts = hosts
-	opt.ReadOnly = rwType.IsReadOnly()
-	opt.Database = viper.GetInt(rwType.FmtSuffix("REDIS_DB_NAME"))
-	opt.Password = viper.GetString(rwType.FmtSuffix("REDIS_DB_PASSWORD"))
-	opt.KeyPrefix = viper.GetString(rwType.FmtSuffix("REDIS_KEY_PREFIX"))
-	// various timeout setting
-	opt.DialTimeout = viper.GetDuration(rwType.FmtSuffix("REDIS_TIMEOUT")) * time.Second
-	opt.ReadTimeout = viper.GetDuration(rwType.FmtSuffix("REDIS_TIMEOUT")) * time.Second
-	opt.WriteTimeout = viper.GetDuration(rwType.FmtSuffix("REDIS_TIMEOUT")) * time.Second
-	// REDIS_MAX_CONNECTIONS
-	opt.PoolSize = viper.GetInt(rwType.FmtSuffix("REDIS_MAX_CONNECTIONS"))
-	opt.PoolTimeout = viper.GetDuration(rwType.FmtSuffix("REDIS_TIMEOUT")) * time.Second
-	opt.IdleTimeout = viper.GetDuration(rwType.FmtSuffix("REDIS_TIMEOUT")) * time.Second
-	opt.IdleCheckFrequency = viper.GetDuration(rwType.FmtSuffix("REDIS_TIMEOUT")) * time.Second
-	opt.TLSConfig = nil
-	return &opt
+	opt.ReadOnly = p.getBool("ReadOnly", "REDIS_READ_ONLY") || rwType.IsReadOnly()
+	opt.Database = p.getInt("Database", "REDIS_DB_NAME")
+	opt.Password = p.getString("Password", "REDIS_DB_PASSWORD")
+	opt.KeyPrefix = p.getString("KeyPrefix", "REDIS_KEY_PREFIX")
+	opt.HashTagPattern = p.getString("HashTagPattern", "REDIS_HASH_TAG_PATTERN")
+	opt.SkipFullCoverCheck = p.getBool("SkipFullCoverCheck", "REDIS_SKIP_FULL_COVER_CHECK")
+	opt.MaxRedirects = p.getInt("MaxRedirects", "REDIS_MAX_REDIRECTS")
+	opt.RouteByLatency = p.getBool("RouteByLatency", "REDIS_ROUTE_BY_LATENCY")
+	// REDIS_POOL_SIZE, REDIS_MAX_CONNECTIONS is the old name
+	opt.PoolSize = p.getInt("PoolSize", p.lookup("REDIS_POOL_SIZE", "REDIS_MAX_CONNECTIONS"))
+	if err := customizedTimeouts(p, &opt); err != nil {
+		return nil, nil, err
+	}
+	tlsConfig, err := tlsStructure(p, hosts)
+	if err != nil {
+		return nil, nil, err
+	}
+	opt.TLSConfig = tlsConfig
+	// sentinel settings, sentinel hosts fall back to REDIS_HOST when absent
+	opt.MasterName = p.getString("MasterName", "REDIS_SENTINEL_MASTER")
+	if sentinelHosts := p.getStringSlice("SentinelAddrs", "REDIS_SENTINEL_HOST"); len(sentinelHosts) > 0 {
+		opt.SentinelAddrs = addrStructureWithPort(p.getStringSlice("SentinelAddrs", "REDIS_SENTINEL_PORT"),
+			sentinelHosts, defaultSentinelPort)
+	}
+	// ring settings, shards are written as name=host:port
+	opt.Shards = shardStructure(p.getStringSlice("Shards", "REDIS_RING_SHARDS"))
+	return &opt, p.report(&opt), nil
+}
+
+// customizedTimeouts reads the timeouts, REDIS_TIMEOUT is the fallback of the
+// dial, read, write, pool and idle timeouts and of the idle check frequency
+func customizedTimeouts(p *paramReader, opt *Options) error {
+	var err error
+	durations := []struct {
+		value    *time.Duration
+		field    string
+		name     string
+		fallback []string
+	}{
+		{&opt.DialTimeout, "DialTimeout", "REDIS_DIAL_TIMEOUT", []string{"REDIS_TIMEOUT"}},
+		{&opt.ReadTimeout, "ReadTimeout", "REDIS_READ_TIMEOUT", []string{"REDIS_TIMEOUT"}},
+		{&opt.WriteTimeout, "WriteTimeout", "REDIS_WRITE_TIMEOUT", []string{"REDIS_TIMEOUT"}},
+		{&opt.PoolTimeout, "PoolTimeout", "REDIS_POOL_TIMEOUT", []string{"REDIS_TIMEOUT"}},
+		{&opt.IdleTimeout, "IdleTimeout", "REDIS_IDLE_TIMEOUT", []string{"REDIS_TIMEOUT"}},
+		{&opt.IdleCheckFrequency, "IdleCheckFrequency", "REDIS_IDLE_CHECK_FREQUENCY", []string{"REDIS_TIMEOUT"}},
+		{&opt.HeartbeatFrequency, "HeartbeatFrequency", "REDIS_HEARTBEAT_FREQUENCY", nil},
+	}
+	for _, d := range durations {
+		if *d.value, err = p.getDuration(d.field, d.name, d.fallback...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// customizedOptionFromURL create options from REDIS_URL, params which can
+// not be written in the URL are still read from viper
+func customizedOptionFromURL(p *paramReader, redisURL string) (*Options, *ConfigReport, error) {
+	opt, err := ParseURL(redisURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	opt.ReadOnly = opt.ReadOnly || p.rwType.IsReadOnly()
+	if opt.KeyPrefix == "" {
+		opt.KeyPrefix = p.getString("KeyPrefix", "REDIS_KEY_PREFIX")
+	}
+	if opt.HashTagPattern == "" {
+		opt.HashTagPattern = p.getString("HashTagPattern", "REDIS_HASH_TAG_PATTERN")
+	}
+	if !opt.SkipFullCoverCheck {
+		opt.SkipFullCoverCheck = p.getBool("SkipFullCoverCheck", "REDIS_SKIP_FULL_COVER_CHECK")
+	}
+	tlsConfig, err := tlsStructure(p, opt.Hosts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if tlsConfig != nil {
+		opt.TLSConfig = tlsConfig
+	}
+	return opt, p.report(opt), nil
 }
 
 // customizedOptionsFromVolume Customized Options by  Volume
 func customizedOptionsFromVolume(rwType RWType) (*Options, error) {
-	fromVolume, err := util.LoadParamsFromVolume()
+	fromVolume, sources, err := util.LoadParamsFromVolumeWithSources()
 	if err != nil {
 		return nil, err
 	}
-	return customizedOption(fromVolume, rwType), nil
+	return customizedOptionLogged(fromVolume, rwType, sources)
 }
 
 // customizedOptionsFromEnv Customized Options by  Env
 func customizedOptionsFromEnv(rwType RWType) (*Options, error) {
-	fromEnv := util.LoadParamsFromEnv()
-	return customizedOption(fromEnv, rwType), nil
+	fromEnv, sources, err := util.LoadParamsFromEnvWithSources()
+	if err != nil {
+		return nil, err
+	}
+	return customizedOptionLogged(fromEnv, rwType, sources)
 }
 
 // customizedOptionsFromFullVariable Customized Options by  Volume and Env
 func customizedOptionsFromFullVariable(rwType RWType) (*Options, error) {
-	mixedViper, err := util.LoadMixedParams()
+	mixedViper, sources, err := util.LoadMixedParamsWithSources()
 	if err != nil {
 		return nil, err
 	}
-	return customizedOption(mixedViper, rwType), nil
+	return customizedOptionLogged(mixedViper, rwType, sources)
+}
+
+// customizedOptionLogged create options and logs the effective
+// configuration when REDIS_LOG_CONFIG is set
+func customizedOptionLogged(viper *viper.Viper, rwType RWType, sources *util.Sources) (*Options, error) {
+	opt, report, err := customizedOptionReport(viper, rwType, sources)
+	if err == nil && viper.GetBool(rwType.FmtSuffix("REDIS_LOG_CONFIG")) {
+		report.Log()
+	}
+	return opt, err
 }
 
 // letOldEnvSupportViper is let old env support viper
@@ -93,6 +241,9 @@ func letOldEnvSupportViper(v *viper.Viper, rwType RWType) {
 	convertDataKey := []string{
 		"REDIS_HOST",
 		"REDIS_PORT",
+		"REDIS_SENTINEL_HOST",
+		"REDIS_SENTINEL_PORT",
+		"REDIS_RING_SHARDS",
 	}
 	for _, k := range convertDataKey {
 		res := v.GetString(rwType.FmtSuffix(k))
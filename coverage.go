@@ -0,0 +1,86 @@
+package redisClient
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-redis/redis"
+)
+
+// ClusterSlotCount is the number of hash slots of a redis cluster
+const ClusterSlotCount = 16384
+
+// SlotRange a range of cluster hash slots, both ends included
+type SlotRange struct {
+	Start int
+	End   int
+}
+
+func (s SlotRange) String() string {
+	if s.Start == s.End {
+		return fmt.Sprintf("%d", s.Start)
+	}
+	return fmt.Sprintf("%d-%d", s.Start, s.End)
+}
+
+// CoverageError is returned when cluster hash slots are not fully served
+type CoverageError struct {
+	Uncovered []SlotRange
+}
+
+func (e *CoverageError) Error() string {
+	ranges := make([]string, len(e.Uncovered))
+	for i, r := range e.Uncovered {
+		ranges[i] = r.String()
+	}
+	return fmt.Sprintf("redis: cluster slots are not fully covered, uncovered ranges: %s",
+		strings.Join(ranges, ", "))
+}
+
+// CheckClusterCoverage verifies that all 16384 hash slots are served by a master,
+// it is a no-op for other client types. The error of a client which could
+// not be created, .e.g. with TLS, is returned
+func (r *Client) CheckClusterCoverage() error {
+	if !r.IsCluster() {
+		return nil
+	}
+	client, ok := r.cmd().(redis.Cmdable)
+	if !ok {
+		return ErrNotImplemented
+	}
+	slots, err := client.ClusterSlots().Result()
+	if err != nil {
+		return err
+	}
+	if uncovered := UncoveredSlots(slots); len(uncovered) > 0 {
+		return &CoverageError{Uncovered: uncovered}
+	}
+	return nil
+}
+
+// UncoveredSlots returns the slot ranges without any serving node
+func UncoveredSlots(slots []redis.ClusterSlot) []SlotRange {
+	covered := make([]bool, ClusterSlotCount)
+	for _, slot := range slots {
+		if len(slot.Nodes) == 0 {
+			continue
+		}
+		for i := slot.Start; i <= slot.End && i < ClusterSlotCount; i++ {
+			if i >= 0 {
+				covered[i] = true
+			}
+		}
+	}
+	uncovered := []SlotRange{}
+	for i := 0; i < ClusterSlotCount; i++ {
+		if covered[i] {
+			continue
+		}
+		if n := len(uncovered); n > 0 && uncovered[n-1].End == i-1 {
+			uncovered[n-1].End = i
+		} else {
+			uncovered = append(uncovered, SlotRange{Start: i, End: i})
+		}
+	}
+	return uncovered
+}
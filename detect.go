@@ -0,0 +1,175 @@
+package redisClient
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+
+	"github.com/go-redis/redis"
+	"github.com/sirupsen/logrus"
+)
+
+// errNoPasswordSet is returned by servers without requirepass, .e.g. sentinels
+const errNoPasswordSet = "no password is set"
+
+// detectTopology probes the seed hosts and returns a copy of opts with
+// the detected Type, the sentinel master name is filled when only one
+// master is monitored
+func detectTopology(opts Options) (Options, error) {
+	seeds := append(append([]string{}, opts.SentinelAddrs...), opts.Hosts...)
+	if len(seeds) == 0 {
+		return opts, errors.New("redis: no hosts to detect topology")
+	}
+	var errs []string
+	for _, addr := range seeds {
+		clientType, masterName, err := probeHost(opts, addr)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", addr, err))
+			continue
+		}
+		opts.Type = clientType
+		if clientType == ClientSentinel {
+			if len(opts.SentinelAddrs) == 0 {
+				opts.SentinelAddrs = opts.Hosts
+			}
+			if opts.MasterName == "" {
+				opts.MasterName = masterName
+			}
+		}
+		return opts, nil
+	}
+	return opts, fmt.Errorf("redis: detect topology failed: %s", strings.Join(errs, "; "))
+}
+
+// probeHost asks a single host for its role using ROLE, CLUSTER INFO and
+// SENTINEL masters. ROLE may be denied by ACLs or unknown to old servers,
+// the other probes still run then
+func probeHost(opts Options, addr string) (ClientType, string, error) {
+	role, roleErr := probeRole(opts, addr, opts.Password)
+	if roleErr != nil && strings.Contains(roleErr.Error(), errNoPasswordSet) {
+		// sentinels are usually deployed without password
+		role, roleErr = probeRole(opts, addr, "")
+	}
+	if isNetworkError(roleErr) {
+		return "", "", roleErr
+	}
+	if role != "sentinel" {
+		c := newProbeClient(opts, addr, opts.Password)
+		defer c.Close()
+		info, err := c.ClusterInfo().Result()
+		if err == nil && strings.Contains(info, "cluster_state:") {
+			return ClientCluster, "", nil
+		}
+		if roleErr == nil {
+			return ClientNormal, "", nil
+		}
+	}
+
+	c := newProbeClient(opts, addr, "")
+	defer c.Close()
+	cmd := redis.NewSliceCmd("sentinel", "masters")
+	c.Process(cmd)
+	masters, err := cmd.Result()
+	if err != nil {
+		if role == "sentinel" {
+			return "", "", err
+		}
+		logrus.Warnf("redis: ROLE of %s failed: %v, Use type:%s", addr, roleErr, ClientNormal)
+		return ClientNormal, "", nil
+	}
+	names := sentinelMasterNames(masters)
+	if opts.MasterName != "" {
+		for _, name := range names {
+			if name == opts.MasterName {
+				return ClientSentinel, name, nil
+			}
+		}
+		return "", "", fmt.Errorf("sentinel does not monitor master %q", opts.MasterName)
+	}
+	if len(names) != 1 {
+		return "", "", fmt.Errorf("sentinel monitors %d masters %v, MasterName is required", len(names), names)
+	}
+	return ClientSentinel, names[0], nil
+}
+
+// probeRole returns the first element of the ROLE reply: master, slave or sentinel
+func probeRole(opts Options, addr, password string) (string, error) {
+	c := newProbeClient(opts, addr, password)
+	defer c.Close()
+	cmd := redis.NewSliceCmd("role")
+	c.Process(cmd)
+	reply, err := cmd.Result()
+	if err != nil {
+		return "", err
+	}
+	if len(reply) == 0 {
+		return "", errors.New("empty ROLE reply")
+	}
+	role, ok := reply[0].(string)
+	if !ok {
+		return "", fmt.Errorf("unexpected ROLE reply %v", reply[0])
+	}
+	return role, nil
+}
+
+// isNetworkError reports whether err comes from the connection rather
+// than from a reply of the server
+func isNetworkError(err error) bool {
+	if err == io.EOF {
+		return true
+	}
+	_, ok := err.(net.Error)
+	return ok
+}
+
+// newProbeClient creates a single connection client used for probing
+func newProbeClient(opts Options, addr, password string) *redis.Client {
+	return redis.NewClient(&redis.Options{
+		Network:      opts.Network,
+		Addr:         addr,
+		Password:     password,
+		DialTimeout:  opts.DialTimeout,
+		ReadTimeout:  opts.ReadTimeout,
+		WriteTimeout: opts.WriteTimeout,
+		PoolSize:     1,
+		TLSConfig:    opts.TLSConfig,
+	})
+}
+
+// sentinelMasterNames reads the name field of each SENTINEL masters entry
+func sentinelMasterNames(masters []interface{}) []string {
+	names := []string{}
+	for _, master := range masters {
+		fields, ok := master.([]interface{})
+		if !ok {
+			continue
+		}
+		for i := 0; i+1 < len(fields); i += 2 {
+			if key, _ := fields[i].(string); key == "name" {
+				if name, ok := fields[i+1].(string); ok {
+					names = append(names, name)
+				}
+				break
+			}
+		}
+	}
+	return names
+}
+
+// resolveAutoType replaces ClientAuto with the detected type, falling back
+// to ClientNormal when no seed host answers
+func resolveAutoType(opts Options) Options {
+	if opts.Type != ClientAuto {
+		return opts
+	}
+	detected, err := detectTopology(opts)
+	if err != nil {
+		logrus.Warnf("%v, Use default type:%s", err, ClientNormal)
+		detected.Type = ClientNormal
+		return detected
+	}
+	logrus.Infof("redis: detected client type:%s", detected.Type)
+	return detected
+}
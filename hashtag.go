@@ -0,0 +1,89 @@
+package redisClient
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// CrossSlotError is returned before sending a multi-key command whose keys
+// are stored in different cluster slots
+type CrossSlotError struct {
+	Keys  []string
+	Slots []int
+}
+
+func (e *CrossSlotError) Error() string {
+	return fmt.Sprintf("redis: CROSSSLOT keys %q hash to slots %v, wrap their common part in a hash tag "+
+		"such as {user1}, use HashTagNamespace or set Options.HashTagPattern", e.Keys, e.Slots)
+}
+
+// hashTagFunc returns the function extracting the hash tag of a key,
+// HashTagFunc has priority over HashTagPattern, nil when both are unset
+func (o Options) hashTagFunc() func(key string) string {
+	if o.HashTagFunc != nil {
+		return o.HashTagFunc
+	}
+	if o.HashTagPattern == "" {
+		return nil
+	}
+	pattern, err := regexp.Compile(o.HashTagPattern)
+	if err != nil {
+		logrus.Warnf("HashTagPattern %q is invalid, keys are not tagged: %v", o.HashTagPattern, err)
+		return nil
+	}
+	return func(key string) string {
+		match := pattern.FindStringSubmatch(key)
+		if len(match) > 1 {
+			return match[1]
+		}
+		if len(match) == 1 {
+			return match[0]
+		}
+		return ""
+	}
+}
+
+// tagKey wraps the hash tag of key in braces, .e.g. tenant:1:orders
+// becomes {tenant:1}:orders, keys already having a hash tag are unchanged
+func tagKey(key string, hashTag func(key string) string) string {
+	if hashTag == nil || hasHashTag(key) {
+		return key
+	}
+	tag := hashTag(key)
+	if tag == "" {
+		return key
+	}
+	if i := strings.Index(key, tag); i >= 0 {
+		return key[:i] + "{" + tag + "}" + key[i+len(tag):]
+	}
+	return "{" + tag + "}" + key
+}
+
+// hasHashTag determine whether key has a non empty {tag}
+func hasHashTag(key string) bool {
+	if s := strings.IndexByte(key, '{'); s > -1 {
+		return strings.IndexByte(key[s+1:], '}') > 0
+	}
+	return false
+}
+
+// checkSlots returns a CrossSlotError when a cluster client is given
+// prefixed keys of different slots, it is a no-op for other client types
+func (r *Client) checkSlots(keys ...string) error {
+	if len(keys) < 2 || !r.IsCluster() {
+		return nil
+	}
+	slots := make([]int, len(keys))
+	crossed := false
+	for i, key := range keys {
+		slots[i] = hashSlot(key)
+		crossed = crossed || slots[i] != slots[0]
+	}
+	if crossed {
+		return &CrossSlotError{Keys: keys, Slots: slots}
+	}
+	return nil
+}
@@ -15,6 +15,7 @@ type Pinger interface {
 type Incrementer interface {
 	Incr(key string) *redis.IntCmd
 	IncrBy(key string, value int64) *redis.IntCmd
+	IncrByFloat(key string, value float64) *redis.FloatCmd
 }
 
 // Decremeter interface to decrement
@@ -44,6 +45,7 @@ type Getter interface {
 	GetSet(key string, value interface{}) *redis.StringCmd
 	MGet(keys ...string) *redis.SliceCmd
 	Dump(key string) *redis.StringCmd
+	StrLen(key string) *redis.IntCmd
 }
 
 // Setter interface for setting key commands
@@ -52,9 +54,40 @@ type Setter interface {
 	Append(key, value string) *redis.IntCmd
 	Del(keys ...string) *redis.IntCmd
 	Unlink(keys ...string) *redis.IntCmd
+	SetNX(key string, value interface{}, expiration time.Duration) *redis.BoolCmd
+	SetXX(key string, value interface{}, expiration time.Duration) *redis.BoolCmd
+	SetRange(key string, offset int64, value string) *redis.IntCmd
 
-	// MSet(pairs ...interface{}) *redis.StatusCmd
-	// MSetNX(pairs ...interface{}) *redis.BoolCmd
+	MSet(pairs ...interface{}) *redis.StatusCmd
+	MSetNX(pairs ...interface{}) *redis.BoolCmd
+}
+
+// Bitmapper interface for bitmap commands, GetBit is in Getter
+type Bitmapper interface {
+	SetBit(key string, offset int64, value int) *redis.IntCmd
+	BitCount(key string, bitCount *redis.BitCount) *redis.IntCmd
+	BitPos(key string, bit int64, pos ...int64) *redis.IntCmd
+	BitOpAnd(destKey string, keys ...string) *redis.IntCmd
+	BitOpOr(destKey string, keys ...string) *redis.IntCmd
+	BitOpXor(destKey string, keys ...string) *redis.IntCmd
+	BitOpNot(destKey string, key string) *redis.IntCmd
+}
+
+// KeyManager interface for key management commands, keys returned by
+// Keys and RandomKey are stripped from the prefix by Client, PrefixedPipeline and Tx
+type KeyManager interface {
+	Keys(pattern string) *redis.StringSliceCmd
+	RandomKey() *redis.StringCmd
+	Rename(key, newkey string) *redis.StatusCmd
+	RenameNX(key, newkey string) *redis.BoolCmd
+	Restore(key string, ttl time.Duration, value string) *redis.StatusCmd
+	RestoreReplace(key string, ttl time.Duration, value string) *redis.StatusCmd
+	Move(key string, db int64) *redis.BoolCmd
+	ObjectRefCount(key string) *redis.IntCmd
+	ObjectEncoding(key string) *redis.StringCmd
+	ObjectIdleTime(key string) *redis.DurationCmd
+	Sort(key string, sort redis.Sort) *redis.StringSliceCmd
+	SortInterfaces(key string, sort redis.Sort) *redis.SliceCmd
 }
 
 // Hasher interface for hashtable commands
@@ -160,6 +193,50 @@ type BlockedSettable interface {
 	BRPopLPush(source, destination string, timeout time.Duration) *redis.StringCmd
 }
 
+// HyperLogLogger interface for HyperLogLog commands
+type HyperLogLogger interface {
+	PFAdd(key string, els ...interface{}) *redis.IntCmd
+	PFCount(keys ...string) *redis.IntCmd
+	PFMerge(dest string, keys ...string) *redis.StatusCmd
+}
+
+// Geospatial interface for geo commands
+type Geospatial interface {
+	GeoAdd(key string, geoLocation ...*redis.GeoLocation) *redis.IntCmd
+	GeoRadius(key string, longitude, latitude float64, query *redis.GeoRadiusQuery) *redis.GeoLocationCmd
+	GeoRadiusRO(key string, longitude, latitude float64, query *redis.GeoRadiusQuery) *redis.GeoLocationCmd
+	GeoRadiusByMember(key, member string, query *redis.GeoRadiusQuery) *redis.GeoLocationCmd
+	GeoRadiusByMemberRO(key, member string, query *redis.GeoRadiusQuery) *redis.GeoLocationCmd
+	GeoDist(key string, member1, member2, unit string) *redis.FloatCmd
+	GeoHash(key string, members ...string) *redis.StringSliceCmd
+	GeoPos(key string, members ...string) *redis.GeoPosCmd
+}
+
+// Scripter interface for lua scripting commands, KEYS are prefixed
+type Scripter interface {
+	Eval(script string, keys []string, args ...interface{}) *redis.Cmd
+	EvalSha(sha1 string, keys []string, args ...interface{}) *redis.Cmd
+	ScriptExists(hashes ...string) *redis.BoolSliceCmd
+	ScriptLoad(script string) *redis.StringCmd
+}
+
+// Admin interface for server introspection, the Nodes methods return the
+// result of every node by address, the others aggregate them
+type Admin interface {
+	Info(section string) (*ServerInfo, error)
+	NodesInfo(section string) (map[string]*ServerInfo, error)
+	DBSize() (int64, error)
+	NodesDBSize() (map[string]int64, error)
+	ConfigGet(parameter string) (map[string]string, error)
+	NodesConfigGet(parameter string) (map[string]map[string]string, error)
+	SlowLog(n int64) ([]SlowLogEntry, error)
+	NodesSlowLog(n int64) (map[string][]SlowLogEntry, error)
+	Time() (time.Time, error)
+	NodesTime() (map[string]time.Time, error)
+	ClientList() ([]ClientInfo, error)
+	NodesClientList() (map[string][]ClientInfo, error)
+}
+
 type Publisher interface {
 	Publish(channel string, message interface{}) *redis.IntCmd
 }
@@ -169,6 +246,7 @@ type Subscriber interface {
 }
 type Pipeline interface {
 	Pipeline() redis.Pipeliner
+	TxPipeline() redis.Pipeliner
 }
 
 // Commander an interface containing all methods
@@ -178,12 +256,17 @@ type Commander interface {
 	Decremeter
 	Expirer
 	Getter
+	KeyManager
 	Hasher
 	Lister
 	Setter
+	Bitmapper
 	Settable
 	SortedSettable
 	BlockedSettable
+	HyperLogLogger
+	Geospatial
+	Scripter
 	Scanner
 	Publisher
 	Subscriber
@@ -0,0 +1,182 @@
+package redisClient
+
+import (
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// randomKeyAttempts is the number of RANDOMKEY calls to find a key having the prefix
+const randomKeyAttempts = 16
+
+// processor is implemented by all the clients of the driver
+type processor interface {
+	Process(cmd redis.Cmder) error
+}
+
+// -------------- KeyManager
+
+// Keys returns the keys matching pattern without prefix, every master of a
+// cluster and every shard of a ring are queried
+func (r *Client) Keys(pattern string) *redis.StringSliceCmd {
+	nodes, err := r.scanNodes()
+	if err != nil {
+		return redis.NewStringSliceResult(nil, err)
+	}
+	prefix := r.k("")
+	keys := []string{}
+	for _, node := range nodes {
+		res, err := node.Keys(r.k(pattern)).Result()
+		if err != nil {
+			return redis.NewStringSliceResult(nil, err)
+		}
+		for _, key := range res {
+			keys = append(keys, strings.TrimPrefix(key, prefix))
+		}
+	}
+	return redis.NewStringSliceResult(keys, nil)
+}
+
+// RandomKey returns a random key having the prefix without it. When a few
+// attempts miss, the first key of a SCAN of the prefix is returned instead
+// and redis.Nil only when there is no such key
+func (r *Client) RandomKey() *redis.StringCmd {
+	prefix := r.k("")
+	if prefix == "" {
+		return r.cmd().RandomKey()
+	}
+	for i := 0; i < randomKeyAttempts; i++ {
+		key, err := r.cmd().RandomKey().Result()
+		if err != nil {
+			return redis.NewStringResult("", err)
+		}
+		if strings.HasPrefix(key, prefix) {
+			return redis.NewStringResult(strings.TrimPrefix(key, prefix), nil)
+		}
+	}
+	it := r.ScanIterator(ScanOptions{})
+	if it.Next() {
+		return redis.NewStringResult(it.Val(), nil)
+	}
+	if err := it.Err(); err != nil {
+		return redis.NewStringResult("", err)
+	}
+	return redis.NewStringResult("", redis.Nil)
+}
+func (r *Client) Rename(key, newkey string) *redis.StatusCmd {
+	key, newkey = r.k(key), r.k(newkey)
+	if err := r.checkSlots(key, newkey); err != nil {
+		return redis.NewStatusResult("", err)
+	}
+	return r.cmd().Rename(key, newkey)
+}
+func (r *Client) RenameNX(key, newkey string) *redis.BoolCmd {
+	key, newkey = r.k(key), r.k(newkey)
+	if err := r.checkSlots(key, newkey); err != nil {
+		return redis.NewBoolResult(false, err)
+	}
+	return r.cmd().RenameNX(key, newkey)
+}
+
+// Restore restores a value returned by Dump
+func (r *Client) Restore(key string, ttl time.Duration, value string) *redis.StatusCmd {
+	return r.cmd().Restore(r.k(key), ttl, value)
+}
+func (r *Client) RestoreReplace(key string, ttl time.Duration, value string) *redis.StatusCmd {
+	return r.cmd().RestoreReplace(r.k(key), ttl, value)
+}
+func (r *Client) Move(key string, db int64) *redis.BoolCmd {
+	return r.cmd().Move(r.k(key), db)
+}
+func (r *Client) ObjectRefCount(key string) *redis.IntCmd {
+	return r.cmd().ObjectRefCount(r.k(key))
+}
+func (r *Client) ObjectEncoding(key string) *redis.StringCmd {
+	return r.cmd().ObjectEncoding(r.k(key))
+}
+func (r *Client) ObjectIdleTime(key string) *redis.DurationCmd {
+	return r.cmd().ObjectIdleTime(r.k(key))
+}
+
+// Sort sort command, the BY, GET and STORE patterns are prefixed,
+// use SortStore to get the length of a stored result
+func (r *Client) Sort(key string, sort redis.Sort) *redis.StringSliceCmd {
+	key, sort = r.k(key), r.kSort(sort)
+	if sort.Store != "" {
+		if err := r.checkSlots(key, sort.Store); err != nil {
+			return redis.NewStringSliceResult(nil, err)
+		}
+	}
+	return r.cmd().Sort(key, sort)
+}
+func (r *Client) SortInterfaces(key string, sort redis.Sort) *redis.SliceCmd {
+	key, sort = r.k(key), r.kSort(sort)
+	if sort.Store != "" {
+		if err := r.checkSlots(key, sort.Store); err != nil {
+			return redis.NewSliceResult(nil, err)
+		}
+	}
+	return r.cmd().SortInterfaces(key, sort)
+}
+
+// SortStore sorts key into store and returns the number of stored elements
+func (r *Client) SortStore(key, store string, sort redis.Sort) *redis.IntCmd {
+	sort.Store = store
+	key, sort = r.k(key), r.kSort(sort)
+	if err := r.checkSlots(key, sort.Store); err != nil {
+		return redis.NewIntResult(0, err)
+	}
+	cmd := redis.NewIntCmd(sortArgs(key, sort)...)
+	if p, ok := r.cmd().(processor); ok {
+		p.Process(cmd)
+		return cmd
+	}
+	return redis.NewIntResult(0, ErrNotImplemented)
+}
+
+// kSort returns sort with prefixed BY, GET and STORE patterns,
+// BY nosort and GET # are kept
+func (r *Client) kSort(sort redis.Sort) redis.Sort {
+	if sort.By != "" && sort.By != "nosort" {
+		sort.By = r.k(sort.By)
+	}
+	if len(sort.Get) > 0 {
+		get := make([]string, len(sort.Get))
+		for i, pattern := range sort.Get {
+			if pattern != "#" {
+				pattern = r.k(pattern)
+			}
+			get[i] = pattern
+		}
+		sort.Get = get
+	}
+	if sort.Store != "" {
+		sort.Store = r.k(sort.Store)
+	}
+	return sort
+}
+
+// sortArgs returns the arguments of a SORT command, as the driver does
+func sortArgs(key string, sort redis.Sort) []interface{} {
+	args := []interface{}{"sort", key}
+	if sort.By != "" {
+		args = append(args, "by", sort.By)
+	}
+	if sort.Offset != 0 || sort.Count != 0 {
+		args = append(args, "limit", sort.Offset, sort.Count)
+	}
+	for _, get := range sort.Get {
+		args = append(args, "get", get)
+	}
+	if sort.Order != "" {
+		args = append(args, sort.Order)
+	}
+	if sort.IsAlpha {
+		args = append(args, "alpha")
+	}
+	if sort.Store != "" {
+		args = append(args, "store", sort.Store)
+	}
+	return args
+}
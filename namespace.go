@@ -0,0 +1,16 @@
+package redisClient
+
+// Namespace returns a client whose keys are prefixed by prefix after the
+// prefix of r, .e.g. app:orders:key. It shares the connection of r and
+// closing it is a no-op
+func (r *Client) Namespace(prefix string) *Client {
+	return &Client{parent: r, namespace: prefix}
+}
+
+// HashTagNamespace is Namespace wrapping prefix in a cluster hash tag,
+// .e.g. app:{orders}key, so all the keys of the namespace are stored in
+// the same slot and can be used together by multi-key commands.
+// A hash tag in the prefix of r has priority
+func (r *Client) HashTagNamespace(prefix string) *Client {
+	return r.Namespace("{" + prefix + "}")
+}
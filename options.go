@@ -12,6 +12,7 @@ type Options struct {
 	Type ClientType
 	// Host address with port number
 	// For normal client will only used the first value
+	// For unix network it is the socket path
 	Hosts []string
 
 	// The network type, either tcp or unix.
@@ -23,6 +24,14 @@ type Options struct {
 	Database int
 	// Automatically adds a prefix to all keys
 	KeyPrefix string
+	// Wraps the part of keys matching this regexp in a cluster hash tag,
+	// .e.g. ^tenant:\d+ stores tenant:1:orders as {tenant:1}:orders so all
+	// the keys of a tenant are in one slot. The first group is used if any.
+	// Keys already having a {tag} are unchanged
+	HashTagPattern string
+	// Returns the hash tag of a key, "" to keep the key unchanged.
+	// Has priority over HashTagPattern
+	HashTagFunc func(key string) string
 
 	// The maximum number of retries before giving up. Command is retried
 	// on network errors and MOVED/ASK redirects.
@@ -30,6 +39,11 @@ type Options struct {
 	// In normal client this is the MaxRetries option
 	MaxRedirects int
 
+	// Skip the startup check that all cluster hash slots are served.
+	// Set it for managed services blocking CLUSTER commands.
+	// Only for cluster client
+	SkipFullCoverCheck bool
+
 	// Enables read queries for a connection to a Redis Cluster slave node.
 	ReadOnly bool
 
@@ -70,8 +84,28 @@ type Options struct {
 	IdleCheckFrequency time.Duration
 
 	// TLS Config to use. When set TLS will be negotiated.
-	// Only for normal client
+	// Only for normal client, the vendored go-redis does not support
+	// TLS for cluster, sentinel and ring clients, their commands fail
+	// instead of connecting without TLS.
+	// Use TLSOptions.Config to build it from mounted files.
 	TLSConfig *tls.Config
+
+	// The master name monitored by sentinels.
+	// Only for sentinel client
+	MasterName string
+	// A seed list of host:port addresses of sentinel nodes.
+	// Only for sentinel client, Hosts will be used when empty
+	SentinelAddrs []string
+
+	// Map of name => host:port addresses of ring shards.
+	// Only for ring client, Hosts will be used when empty and
+	// each shard is named after its address
+	Shards map[string]string
+	// Frequency of PING commands sent to check shards availability.
+	// Shard is considered down after 3 subsequent failed checks.
+	// Default is 500 milliseconds.
+	// Only for ring client
+	HeartbeatFrequency time.Duration
 }
 
 // GetClusterConfig translates current configuration into a *redis.ClusterOptions
@@ -98,7 +132,7 @@ func (o Options) GetClusterConfig() *redis.ClusterOptions {
 // GetNormalConfig translates current configuration into a *redis.Options struct
 func (o Options) GetNormalConfig() *redis.Options {
 	opts := &redis.Options{
-		Addr:               o.Hosts[0],
+		Network:            o.Network,
 		Password:           o.Password,
 		DB:                 o.Database,
 		MaxRetries:         o.MaxRedirects,
@@ -111,6 +145,58 @@ func (o Options) GetNormalConfig() *redis.Options {
 		IdleCheckFrequency: o.IdleCheckFrequency,
 		TLSConfig:          o.TLSConfig,
 	}
+	if len(o.Hosts) > 0 {
+		opts.Addr = o.Hosts[0]
+	}
+	return opts
+}
+
+// GetFailoverConfig translates current configuration into a *redis.FailoverOptions
+func (o Options) GetFailoverConfig() *redis.FailoverOptions {
+	sentinels := o.SentinelAddrs
+	if len(sentinels) == 0 {
+		sentinels = o.Hosts
+	}
+	opts := &redis.FailoverOptions{
+		MasterName:         o.MasterName,
+		SentinelAddrs:      sentinels,
+		Password:           o.Password,
+		DB:                 o.Database,
+		MaxRetries:         o.MaxRedirects,
+		DialTimeout:        o.DialTimeout,
+		ReadTimeout:        o.ReadTimeout,
+		WriteTimeout:       o.WriteTimeout,
+		PoolSize:           o.PoolSize,
+		PoolTimeout:        o.PoolTimeout,
+		IdleTimeout:        o.IdleTimeout,
+		IdleCheckFrequency: o.IdleCheckFrequency,
+	}
+	return opts
+}
+
+// GetRingConfig translates current configuration into a *redis.RingOptions
+func (o Options) GetRingConfig() *redis.RingOptions {
+	shards := o.Shards
+	if len(shards) == 0 {
+		shards = make(map[string]string, len(o.Hosts))
+		for _, host := range o.Hosts {
+			shards[host] = host
+		}
+	}
+	opts := &redis.RingOptions{
+		Addrs:              shards,
+		HeartbeatFrequency: o.HeartbeatFrequency,
+		Password:           o.Password,
+		DB:                 o.Database,
+		MaxRetries:         o.MaxRedirects,
+		DialTimeout:        o.DialTimeout,
+		ReadTimeout:        o.ReadTimeout,
+		WriteTimeout:       o.WriteTimeout,
+		PoolSize:           o.PoolSize,
+		PoolTimeout:        o.PoolTimeout,
+		IdleTimeout:        o.IdleTimeout,
+		IdleCheckFrequency: o.IdleCheckFrequency,
+	}
 	return opts
 }
 
@@ -122,8 +208,33 @@ const (
 	ClientNormal ClientType = "normal"
 	// ClientCluster for official redis cluster
 	ClientCluster ClientType = "cluster"
+	// ClientSentinel for sentinel managed master/slave instances
+	ClientSentinel ClientType = "sentinel"
+	// ClientRing for client side sharding over standalone instances
+	ClientRing ClientType = "ring"
+	// ClientAuto for probing the hosts to choose cluster, sentinel or normal
+	ClientAuto ClientType = "auto"
 )
 
+// IsValid will return whether the client type is known
+func (t ClientType) IsValid() bool {
+	switch t {
+	case ClientNormal, ClientCluster, ClientSentinel, ClientRing, ClientAuto:
+		return true
+	}
+	return false
+}
+
+// supportsTLS will return whether the client type can negotiate TLS,
+// an auto type is checked again once detected
+func (t ClientType) supportsTLS() bool {
+	switch t {
+	case ClientCluster, ClientSentinel, ClientRing:
+		return false
+	}
+	return true
+}
+
 // Client Reader and Writer
 type RWType string
 
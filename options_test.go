@@ -0,0 +1,40 @@
+package redisClient_test
+
+import (
+	"crypto/tls"
+	"testing"
+	"time"
+
+	redis "github.com/alauda/go-redis-client"
+)
+
+func TestOptionsValidate(t *testing.T) {
+	opts := redis.Options{
+		Type:  redis.ClientNormal,
+		Hosts: []string{"127.0.0.1:6379"},
+	}
+	if err := opts.Validate(); err != nil {
+		t.Error("valid options:", err)
+	}
+
+	opts = redis.Options{
+		Type:        redis.ClientCluster,
+		Hosts:       []string{"127.0.0.1", "127.0.0.1:99999"},
+		Database:    1,
+		PoolSize:    -1,
+		DialTimeout: 5 * time.Hour,
+		TLSConfig:   &tls.Config{},
+	}
+	err := opts.Validate()
+	verr, ok := err.(*redis.ValidationError)
+	if !ok {
+		t.Fatal("expected *ValidationError, got:", err)
+	}
+	if len(verr.Problems) != 6 {
+		t.Errorf("expected 6 problems, got %d: %v", len(verr.Problems), verr)
+	}
+
+	if _, err := redis.NewClientE(redis.Options{}); err == nil {
+		t.Error("empty hosts should fail")
+	}
+}
@@ -0,0 +1,76 @@
+package redisClient
+
+import (
+	"github.com/go-redis/redis"
+)
+
+// PrefixedPipeline a pipeline applying the KeyPrefix of its client,
+// commands are queued until Exec is called
+type PrefixedPipeline struct {
+	prefixedCmdable
+	pipe redis.Pipeliner
+	// err is returned by Exec when the pipeline can not be used
+	err error
+}
+
+func newPrefixedPipeline(client *Client, pipe redis.Pipeliner, err error) *PrefixedPipeline {
+	return &PrefixedPipeline{prefixedCmdable: prefixedCmdable{client: client, cmds: pipe}, pipe: pipe, err: err}
+}
+
+// PrefixedPipeline returns a pipeline applying the KeyPrefix
+func (r *Client) PrefixedPipeline() *PrefixedPipeline {
+	return newPrefixedPipeline(r, r.cmd().Pipeline(), nil)
+}
+
+// TxPrefixedPipeline returns a pipeline applying the KeyPrefix wrapped in
+// MULTI/EXEC, Exec returns ErrNotImplemented for ring clients
+func (r *Client) TxPrefixedPipeline() *PrefixedPipeline {
+	if r.IsRing() {
+		return newPrefixedPipeline(r, r.cmd().Pipeline(), ErrNotImplemented)
+	}
+	return newPrefixedPipeline(r, r.cmd().TxPipeline(), nil)
+}
+
+// Pipelined queues the commands of fn and executes them
+func (r *Client) Pipelined(fn func(*PrefixedPipeline) error) ([]redis.Cmder, error) {
+	return r.PrefixedPipeline().pipelined(fn)
+}
+
+// TxPipelined queues the commands of fn and executes them in MULTI/EXEC
+func (r *Client) TxPipelined(fn func(*PrefixedPipeline) error) ([]redis.Cmder, error) {
+	return r.TxPrefixedPipeline().pipelined(fn)
+}
+
+func (p *PrefixedPipeline) pipelined(fn func(*PrefixedPipeline) error) ([]redis.Cmder, error) {
+	defer p.Close()
+	if err := fn(p); err != nil {
+		return nil, err
+	}
+	return p.Exec()
+}
+
+// Exec executes the queued commands, the pipeline can be reused after it.
+// A command whose keys are in different cluster slots is not queued, Exec
+// then discards the other commands and returns its CrossSlotError
+func (p *PrefixedPipeline) Exec() ([]redis.Cmder, error) {
+	err := p.err
+	if err == nil {
+		err = p.slotErr
+	}
+	if err != nil {
+		p.Discard()
+		return nil, err
+	}
+	return p.pipe.Exec()
+}
+
+// Discard discards the queued commands
+func (p *PrefixedPipeline) Discard() error {
+	p.slotErr = nil
+	return p.pipe.Discard()
+}
+
+// Close closes the pipeline, it can not be used any more
+func (p *PrefixedPipeline) Close() error {
+	return p.pipe.Close()
+}
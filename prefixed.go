@@ -0,0 +1,776 @@
+package redisClient
+
+import (
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// prefixedCmdable applies the KeyPrefix of client to the commands of cmds,
+// it is shared by PrefixedPipeline and Tx
+type prefixedCmdable struct {
+	client *Client
+	cmds   cmdableProcessor
+	// slotErr is the first CrossSlotError of the commands not queued
+	slotErr error
+}
+
+// cmdableProcessor is implemented by pipelines and transactions
+type cmdableProcessor interface {
+	redis.Cmdable
+	processor
+}
+
+// Formats and returns the key with the prefix of the client
+func (c *prefixedCmdable) k(key string) string {
+	return c.client.k(key)
+}
+
+// Formats and returns a set of keys using the prefix of the client
+func (c *prefixedCmdable) ks(key ...string) []string {
+	return c.client.ks(key...)
+}
+
+// checkSlots returns a CrossSlotError for prefixed keys of different cluster
+// slots, the first one is kept for Exec to fail
+func (c *prefixedCmdable) checkSlots(keys ...string) error {
+	err := c.client.checkSlots(keys...)
+	if err != nil && c.slotErr == nil {
+		c.slotErr = err
+	}
+	return err
+}
+
+// -------------- Pinger
+
+// Ping sends a Ping command
+func (c *prefixedCmdable) Ping() *redis.StatusCmd {
+	return c.cmds.Ping()
+}
+
+// -------------- Incrementer
+
+// Incr increments the key by 1
+func (c *prefixedCmdable) Incr(key string) *redis.IntCmd {
+	return c.cmds.Incr(c.k(key))
+}
+
+// IncrBy increments using a increment value
+func (c *prefixedCmdable) IncrBy(key string, value int64) *redis.IntCmd {
+	return c.cmds.IncrBy(c.k(key), value)
+}
+
+// -------------- Decrementer
+
+// Decr decrements the key by 1
+func (c *prefixedCmdable) Decr(key string) *redis.IntCmd {
+	return c.cmds.Decr(c.k(key))
+}
+
+// DecrBy decrements using a increment value
+func (c *prefixedCmdable) DecrBy(key string, value int64) *redis.IntCmd {
+	return c.cmds.DecrBy(c.k(key), value)
+}
+
+// -------------- Expirer
+
+// Expire expire method
+func (c *prefixedCmdable) Expire(key string, expiration time.Duration) *redis.BoolCmd {
+	return c.cmds.Expire(c.k(key), expiration)
+}
+
+// ExpireAt expireat method
+func (c *prefixedCmdable) ExpireAt(key string, tm time.Time) *redis.BoolCmd {
+	return c.cmds.ExpireAt(c.k(key), tm)
+}
+
+// Persist persist command
+func (c *prefixedCmdable) Persist(key string) *redis.BoolCmd {
+	return c.cmds.Persist(c.k(key))
+}
+
+// PExpire redis command
+func (c *prefixedCmdable) PExpire(key string, expiration time.Duration) *redis.BoolCmd {
+	return c.cmds.PExpire(c.k(key), expiration)
+}
+func (c *prefixedCmdable) PExpireAt(key string, tm time.Time) *redis.BoolCmd {
+	return c.cmds.PExpireAt(c.k(key), tm)
+}
+func (c *prefixedCmdable) PTTL(key string) *redis.DurationCmd {
+	return c.cmds.PTTL(c.k(key))
+}
+func (c *prefixedCmdable) TTL(key string) *redis.DurationCmd {
+	return c.cmds.TTL(c.k(key))
+}
+
+// -------------- Getter
+
+// Exists exists command
+func (c *prefixedCmdable) Exists(key ...string) *redis.IntCmd {
+	keys := c.ks(key...)
+	if err := c.checkSlots(keys...); err != nil {
+		return redis.NewIntResult(0, err)
+	}
+	return c.cmds.Exists(keys...)
+}
+
+// Get get key value
+func (c *prefixedCmdable) Get(key string) *redis.StringCmd {
+	return c.cmds.Get(c.k(key))
+}
+
+// GetBit getbit key value
+func (c *prefixedCmdable) GetBit(key string, offset int64) *redis.IntCmd {
+	return c.cmds.GetBit(c.k(key), offset)
+}
+
+// GetRange GetRange key value
+func (c *prefixedCmdable) GetRange(key string, start, end int64) *redis.StringCmd {
+	return c.cmds.GetRange(c.k(key), start, end)
+}
+
+// GetSet getset command
+func (c *prefixedCmdable) GetSet(key string, value interface{}) *redis.StringCmd {
+	return c.cmds.GetSet(c.k(key), value)
+}
+
+// MGet Multiple get command
+func (c *prefixedCmdable) MGet(keys ...string) *redis.SliceCmd {
+	keys = c.ks(keys...)
+	if err := c.checkSlots(keys...); err != nil {
+		return redis.NewSliceResult(nil, err)
+	}
+	return c.cmds.MGet(keys...)
+}
+
+// Dump dump command
+func (c *prefixedCmdable) Dump(key string) *redis.StringCmd {
+	return c.cmds.Dump(c.k(key))
+}
+
+// -------------- Hasher
+
+func (c *prefixedCmdable) HExists(key, field string) *redis.BoolCmd {
+	return c.cmds.HExists(c.k(key), field)
+}
+func (c *prefixedCmdable) HGet(key, field string) *redis.StringCmd {
+	return c.cmds.HGet(c.k(key), field)
+}
+func (c *prefixedCmdable) HGetAll(key string) *redis.StringStringMapCmd {
+	return c.cmds.HGetAll(c.k(key))
+}
+func (c *prefixedCmdable) HIncrBy(key, field string, incr int64) *redis.IntCmd {
+	return c.cmds.HIncrBy(c.k(key), field, incr)
+}
+func (c *prefixedCmdable) HIncrByFloat(key, field string, incr float64) *redis.FloatCmd {
+	return c.cmds.HIncrByFloat(c.k(key), field, incr)
+}
+func (c *prefixedCmdable) HKeys(key string) *redis.StringSliceCmd {
+	return c.cmds.HKeys(c.k(key))
+}
+func (c *prefixedCmdable) HLen(key string) *redis.IntCmd {
+	return c.cmds.HLen(c.k(key))
+}
+func (c *prefixedCmdable) HMGet(key string, fields ...string) *redis.SliceCmd {
+	return c.cmds.HMGet(c.k(key), fields...)
+}
+func (c *prefixedCmdable) HMSet(key string, fields map[string]interface{}) *redis.StatusCmd {
+	return c.cmds.HMSet(c.k(key), fields)
+}
+
+func (c *prefixedCmdable) HSet(key, field string, value interface{}) *redis.BoolCmd {
+	return c.cmds.HSet(c.k(key), field, value)
+}
+func (c *prefixedCmdable) HSetNX(key, field string, value interface{}) *redis.BoolCmd {
+	return c.cmds.HSetNX(c.k(key), field, value)
+}
+func (c *prefixedCmdable) HVals(key string) *redis.StringSliceCmd {
+	return c.cmds.HVals(c.k(key))
+}
+func (c *prefixedCmdable) HDel(key string, fields ...string) *redis.IntCmd {
+	return c.cmds.HDel(c.k(key), fields...)
+}
+
+// -------------- Lister
+
+func (c *prefixedCmdable) LIndex(key string, index int64) *redis.StringCmd {
+	return c.cmds.LIndex(c.k(key), index)
+}
+func (c *prefixedCmdable) LInsert(key, op string, pivot, value interface{}) *redis.IntCmd {
+	return c.cmds.LInsert(c.k(key), op, pivot, value)
+}
+func (c *prefixedCmdable) LInsertAfter(key string, pivot, value interface{}) *redis.IntCmd {
+	return c.cmds.LInsertAfter(c.k(key), pivot, value)
+}
+func (c *prefixedCmdable) LInsertBefore(key string, pivot, value interface{}) *redis.IntCmd {
+	return c.cmds.LInsertBefore(c.k(key), pivot, value)
+}
+func (c *prefixedCmdable) LLen(key string) *redis.IntCmd {
+	return c.cmds.LLen(c.k(key))
+}
+func (c *prefixedCmdable) LPop(key string) *redis.StringCmd {
+	return c.cmds.LPop(c.k(key))
+}
+func (c *prefixedCmdable) LPush(key string, values ...interface{}) *redis.IntCmd {
+	return c.cmds.LPush(c.k(key), values...)
+}
+func (c *prefixedCmdable) LPushX(key string, value interface{}) *redis.IntCmd {
+	return c.cmds.LPushX(c.k(key), value)
+}
+func (c *prefixedCmdable) LRange(key string, start, stop int64) *redis.StringSliceCmd {
+	return c.cmds.LRange(c.k(key), start, stop)
+}
+func (c *prefixedCmdable) LRem(key string, count int64, value interface{}) *redis.IntCmd {
+	return c.cmds.LRem(c.k(key), count, value)
+}
+func (c *prefixedCmdable) LSet(key string, index int64, value interface{}) *redis.StatusCmd {
+	return c.cmds.LSet(c.k(key), index, value)
+}
+func (c *prefixedCmdable) LTrim(key string, start, stop int64) *redis.StatusCmd {
+	return c.cmds.LTrim(c.k(key), start, stop)
+}
+func (c *prefixedCmdable) RPop(key string) *redis.StringCmd {
+	return c.cmds.RPop(c.k(key))
+}
+func (c *prefixedCmdable) RPopLPush(source, destination string) *redis.StringCmd {
+	source, destination = c.k(source), c.k(destination)
+	if err := c.checkSlots(source, destination); err != nil {
+		return redis.NewStringResult("", err)
+	}
+	return c.cmds.RPopLPush(source, destination)
+}
+func (c *prefixedCmdable) RPush(key string, values ...interface{}) *redis.IntCmd {
+	return c.cmds.RPush(c.k(key), values...)
+}
+func (c *prefixedCmdable) RPushX(key string, value interface{}) *redis.IntCmd {
+	return c.cmds.RPushX(c.k(key), value)
+}
+
+// -------------- Setter
+
+// Set function
+func (c *prefixedCmdable) Set(key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
+	return c.cmds.Set(c.k(key), value, expiration)
+}
+func (c *prefixedCmdable) Append(key, value string) *redis.IntCmd {
+	return c.cmds.Append(c.k(key), value)
+}
+func (c *prefixedCmdable) Del(keys ...string) *redis.IntCmd {
+	keys = c.ks(keys...)
+	if err := c.checkSlots(keys...); err != nil {
+		return redis.NewIntResult(0, err)
+	}
+	return c.cmds.Del(keys...)
+}
+func (c *prefixedCmdable) Unlink(keys ...string) *redis.IntCmd {
+	keys = c.ks(keys...)
+	if err := c.checkSlots(keys...); err != nil {
+		return redis.NewIntResult(0, err)
+	}
+	return c.cmds.Unlink(keys...)
+}
+
+// -------------- Settable
+
+func (c *prefixedCmdable) SAdd(key string, members ...interface{}) *redis.IntCmd {
+	return c.cmds.SAdd(c.k(key), members...)
+}
+func (c *prefixedCmdable) SCard(key string) *redis.IntCmd {
+	return c.cmds.SCard(c.k(key))
+}
+func (c *prefixedCmdable) SDiff(keys ...string) *redis.StringSliceCmd {
+	keys = c.ks(keys...)
+	if err := c.checkSlots(keys...); err != nil {
+		return redis.NewStringSliceResult(nil, err)
+	}
+	return c.cmds.SDiff(keys...)
+}
+func (c *prefixedCmdable) SDiffStore(destination string, keys ...string) *redis.IntCmd {
+	keys = c.ks(append([]string{destination}, keys...)...)
+	if err := c.checkSlots(keys...); err != nil {
+		return redis.NewIntResult(0, err)
+	}
+	return c.cmds.SDiffStore(keys[0], keys[1:]...)
+}
+func (c *prefixedCmdable) SInter(keys ...string) *redis.StringSliceCmd {
+	keys = c.ks(keys...)
+	if err := c.checkSlots(keys...); err != nil {
+		return redis.NewStringSliceResult(nil, err)
+	}
+	return c.cmds.SInter(keys...)
+}
+func (c *prefixedCmdable) SInterStore(destination string, keys ...string) *redis.IntCmd {
+	keys = c.ks(append([]string{destination}, keys...)...)
+	if err := c.checkSlots(keys...); err != nil {
+		return redis.NewIntResult(0, err)
+	}
+	return c.cmds.SInterStore(keys[0], keys[1:]...)
+}
+func (c *prefixedCmdable) SIsMember(key string, member interface{}) *redis.BoolCmd {
+	return c.cmds.SIsMember(c.k(key), member)
+}
+func (c *prefixedCmdable) SMembers(key string) *redis.StringSliceCmd {
+	return c.cmds.SMembers(c.k(key))
+}
+func (c *prefixedCmdable) SMove(source, destination string, member interface{}) *redis.BoolCmd {
+	source, destination = c.k(source), c.k(destination)
+	if err := c.checkSlots(source, destination); err != nil {
+		return redis.NewBoolResult(false, err)
+	}
+	return c.cmds.SMove(source, destination, member)
+}
+func (c *prefixedCmdable) SPop(key string) *redis.StringCmd {
+	return c.cmds.SPop(c.k(key))
+}
+func (c *prefixedCmdable) SPopN(key string, count int64) *redis.StringSliceCmd {
+	return c.cmds.SPopN(c.k(key), count)
+}
+func (c *prefixedCmdable) SRandMember(key string) *redis.StringCmd {
+	return c.cmds.SRandMember(c.k(key))
+}
+func (c *prefixedCmdable) SRandMemberN(key string, count int64) *redis.StringSliceCmd {
+	return c.cmds.SRandMemberN(c.k(key), count)
+}
+func (c *prefixedCmdable) SRem(key string, members ...interface{}) *redis.IntCmd {
+	return c.cmds.SRem(c.k(key), members...)
+}
+func (c *prefixedCmdable) SUnion(keys ...string) *redis.StringSliceCmd {
+	keys = c.ks(keys...)
+	if err := c.checkSlots(keys...); err != nil {
+		return redis.NewStringSliceResult(nil, err)
+	}
+	return c.cmds.SUnion(keys...)
+}
+func (c *prefixedCmdable) SUnionStore(destination string, keys ...string) *redis.IntCmd {
+	keys = c.ks(append([]string{destination}, keys...)...)
+	if err := c.checkSlots(keys...); err != nil {
+		return redis.NewIntResult(0, err)
+	}
+	return c.cmds.SUnionStore(keys[0], keys[1:]...)
+}
+
+// -------------- SortedSettable
+
+func (c *prefixedCmdable) ZAdd(key string, members ...redis.Z) *redis.IntCmd {
+	return c.cmds.ZAdd(c.k(key), members...)
+}
+func (c *prefixedCmdable) ZAddNX(key string, members ...redis.Z) *redis.IntCmd {
+	return c.cmds.ZAddNX(c.k(key), members...)
+}
+func (c *prefixedCmdable) ZAddXX(key string, members ...redis.Z) *redis.IntCmd {
+	return c.cmds.ZAddXX(c.k(key), members...)
+}
+func (c *prefixedCmdable) ZAddCh(key string, members ...redis.Z) *redis.IntCmd {
+	return c.cmds.ZAddCh(c.k(key), members...)
+}
+func (c *prefixedCmdable) ZAddNXCh(key string, members ...redis.Z) *redis.IntCmd {
+	return c.cmds.ZAddNXCh(c.k(key), members...)
+}
+func (c *prefixedCmdable) ZAddXXCh(key string, members ...redis.Z) *redis.IntCmd {
+	return c.cmds.ZAddXXCh(c.k(key), members...)
+}
+func (c *prefixedCmdable) ZIncr(key string, member redis.Z) *redis.FloatCmd {
+	return c.cmds.ZIncr(c.k(key), member)
+}
+func (c *prefixedCmdable) ZIncrNX(key string, member redis.Z) *redis.FloatCmd {
+	return c.cmds.ZIncrNX(c.k(key), member)
+}
+func (c *prefixedCmdable) ZIncrXX(key string, member redis.Z) *redis.FloatCmd {
+	return c.cmds.ZIncrXX(c.k(key), member)
+}
+func (c *prefixedCmdable) ZCard(key string) *redis.IntCmd {
+	return c.cmds.ZCard(c.k(key))
+}
+func (c *prefixedCmdable) ZCount(key, min, max string) *redis.IntCmd {
+	return c.cmds.ZCount(c.k(key), min, max)
+}
+func (c *prefixedCmdable) ZIncrBy(key string, increment float64, member string) *redis.FloatCmd {
+	return c.cmds.ZIncrBy(c.k(key), increment, member)
+}
+func (c *prefixedCmdable) ZInterStore(key string, store redis.ZStore, keys ...string) *redis.IntCmd {
+	keys = c.ks(append([]string{key}, keys...)...)
+	if err := c.checkSlots(keys...); err != nil {
+		return redis.NewIntResult(0, err)
+	}
+	return c.cmds.ZInterStore(keys[0], store, keys[1:]...)
+}
+func (c *prefixedCmdable) ZRange(key string, start, stop int64) *redis.StringSliceCmd {
+	return c.cmds.ZRange(c.k(key), start, stop)
+}
+func (c *prefixedCmdable) ZRangeWithScores(key string, start, stop int64) *redis.ZSliceCmd {
+	return c.cmds.ZRangeWithScores(c.k(key), start, stop)
+}
+func (c *prefixedCmdable) ZRangeByScore(key string, opt redis.ZRangeBy) *redis.StringSliceCmd {
+	return c.cmds.ZRangeByScore(c.k(key), opt)
+}
+func (c *prefixedCmdable) ZRangeByLex(key string, opt redis.ZRangeBy) *redis.StringSliceCmd {
+	return c.cmds.ZRangeByLex(c.k(key), opt)
+}
+func (c *prefixedCmdable) ZRangeByScoreWithScores(key string, opt redis.ZRangeBy) *redis.ZSliceCmd {
+	return c.cmds.ZRangeByScoreWithScores(c.k(key), opt)
+}
+func (c *prefixedCmdable) ZRank(key, member string) *redis.IntCmd {
+	return c.cmds.ZRank(c.k(key), member)
+}
+func (c *prefixedCmdable) ZRem(key string, members ...interface{}) *redis.IntCmd {
+	return c.cmds.ZRem(c.k(key), members...)
+}
+func (c *prefixedCmdable) ZRemRangeByRank(key string, start, stop int64) *redis.IntCmd {
+	return c.cmds.ZRemRangeByRank(c.k(key), start, stop)
+}
+func (c *prefixedCmdable) ZRemRangeByScore(key, min, max string) *redis.IntCmd {
+	return c.cmds.ZRemRangeByScore(c.k(key), min, max)
+}
+func (c *prefixedCmdable) ZRemRangeByLex(key, min, max string) *redis.IntCmd {
+	return c.cmds.ZRemRangeByLex(c.k(key), min, max)
+}
+func (c *prefixedCmdable) ZRevRange(key string, start, stop int64) *redis.StringSliceCmd {
+	return c.cmds.ZRevRange(c.k(key), start, stop)
+}
+func (c *prefixedCmdable) ZRevRangeWithScores(key string, start, stop int64) *redis.ZSliceCmd {
+	return c.cmds.ZRevRangeWithScores(c.k(key), start, stop)
+}
+func (c *prefixedCmdable) ZRevRangeByScore(key string, opt redis.ZRangeBy) *redis.StringSliceCmd {
+	return c.cmds.ZRevRangeByScore(c.k(key), opt)
+}
+func (c *prefixedCmdable) ZRevRangeByLex(key string, opt redis.ZRangeBy) *redis.StringSliceCmd {
+	return c.cmds.ZRevRangeByLex(c.k(key), opt)
+}
+func (c *prefixedCmdable) ZRevRangeByScoreWithScores(key string, opt redis.ZRangeBy) *redis.ZSliceCmd {
+	return c.cmds.ZRevRangeByScoreWithScores(c.k(key), opt)
+}
+func (c *prefixedCmdable) ZRevRank(key, member string) *redis.IntCmd {
+	return c.cmds.ZRevRank(c.k(key), member)
+}
+func (c *prefixedCmdable) ZScore(key, member string) *redis.FloatCmd {
+	return c.cmds.ZScore(c.k(key), member)
+}
+func (c *prefixedCmdable) ZUnionStore(dest string, store redis.ZStore, keys ...string) *redis.IntCmd {
+	keys = c.ks(append([]string{dest}, keys...)...)
+	if err := c.checkSlots(keys...); err != nil {
+		return redis.NewIntResult(0, err)
+	}
+	return c.cmds.ZUnionStore(keys[0], store, keys[1:]...)
+}
+
+// -------------- BlockedSettable
+
+func (c *prefixedCmdable) BLPop(timeout time.Duration, keys ...string) *redis.StringSliceCmd {
+	keys = c.ks(keys...)
+	if err := c.checkSlots(keys...); err != nil {
+		return redis.NewStringSliceResult(nil, err)
+	}
+	return c.cmds.BLPop(timeout, keys...)
+}
+func (c *prefixedCmdable) BRPop(timeout time.Duration, keys ...string) *redis.StringSliceCmd {
+	keys = c.ks(keys...)
+	if err := c.checkSlots(keys...); err != nil {
+		return redis.NewStringSliceResult(nil, err)
+	}
+	return c.cmds.BRPop(timeout, keys...)
+}
+func (c *prefixedCmdable) BRPopLPush(source, destination string, timeout time.Duration) *redis.StringCmd {
+	source, destination = c.k(source), c.k(destination)
+	if err := c.checkSlots(source, destination); err != nil {
+		return redis.NewStringResult("", err)
+	}
+	return c.cmds.BRPopLPush(source, destination, timeout)
+}
+
+// -------------- Scanner
+
+func (c *prefixedCmdable) Type(key string) *redis.StatusCmd {
+	return c.cmds.Type(c.k(key))
+}
+func (c *prefixedCmdable) Scan(cursor uint64, match string, count int64) *redis.ScanCmd {
+	return c.cmds.Scan(cursor, c.k(match), count)
+}
+func (c *prefixedCmdable) SScan(key string, cursor uint64, match string, count int64) *redis.ScanCmd {
+	return c.cmds.SScan(c.k(key), cursor, match, count)
+}
+func (c *prefixedCmdable) ZScan(key string, cursor uint64, match string, count int64) *redis.ScanCmd {
+	return c.cmds.ZScan(c.k(key), cursor, match, count)
+}
+func (c *prefixedCmdable) HScan(key string, cursor uint64, match string, count int64) *redis.ScanCmd {
+	return c.cmds.HScan(c.k(key), cursor, match, count)
+}
+
+// -------------- Publisher
+
+func (c *prefixedCmdable) Publish(channel string, message interface{}) *redis.IntCmd {
+	return c.cmds.Publish(c.k(channel), message)
+}
+
+// -------------- Strings and Bitmapper
+
+func (c *prefixedCmdable) IncrByFloat(key string, value float64) *redis.FloatCmd {
+	return c.cmds.IncrByFloat(c.k(key), value)
+}
+func (c *prefixedCmdable) StrLen(key string) *redis.IntCmd {
+	return c.cmds.StrLen(c.k(key))
+}
+func (c *prefixedCmdable) SetNX(key string, value interface{}, expiration time.Duration) *redis.BoolCmd {
+	return c.cmds.SetNX(c.k(key), value, expiration)
+}
+func (c *prefixedCmdable) SetXX(key string, value interface{}, expiration time.Duration) *redis.BoolCmd {
+	return c.cmds.SetXX(c.k(key), value, expiration)
+}
+func (c *prefixedCmdable) SetRange(key string, offset int64, value string) *redis.IntCmd {
+	return c.cmds.SetRange(c.k(key), offset, value)
+}
+func (c *prefixedCmdable) MSet(pairs ...interface{}) *redis.StatusCmd {
+	pairs, keys := c.client.kPairs(pairs)
+	if err := c.checkSlots(keys...); err != nil {
+		return redis.NewStatusResult("", err)
+	}
+	return c.cmds.MSet(pairs...)
+}
+func (c *prefixedCmdable) MSetNX(pairs ...interface{}) *redis.BoolCmd {
+	pairs, keys := c.client.kPairs(pairs)
+	if err := c.checkSlots(keys...); err != nil {
+		return redis.NewBoolResult(false, err)
+	}
+	return c.cmds.MSetNX(pairs...)
+}
+func (c *prefixedCmdable) SetBit(key string, offset int64, value int) *redis.IntCmd {
+	return c.cmds.SetBit(c.k(key), offset, value)
+}
+func (c *prefixedCmdable) BitCount(key string, bitCount *redis.BitCount) *redis.IntCmd {
+	return c.cmds.BitCount(c.k(key), bitCount)
+}
+func (c *prefixedCmdable) BitPos(key string, bit int64, pos ...int64) *redis.IntCmd {
+	return c.cmds.BitPos(c.k(key), bit, pos...)
+}
+func (c *prefixedCmdable) BitOpAnd(destKey string, keys ...string) *redis.IntCmd {
+	keys = c.ks(append([]string{destKey}, keys...)...)
+	if err := c.checkSlots(keys...); err != nil {
+		return redis.NewIntResult(0, err)
+	}
+	return c.cmds.BitOpAnd(keys[0], keys[1:]...)
+}
+func (c *prefixedCmdable) BitOpOr(destKey string, keys ...string) *redis.IntCmd {
+	keys = c.ks(append([]string{destKey}, keys...)...)
+	if err := c.checkSlots(keys...); err != nil {
+		return redis.NewIntResult(0, err)
+	}
+	return c.cmds.BitOpOr(keys[0], keys[1:]...)
+}
+func (c *prefixedCmdable) BitOpXor(destKey string, keys ...string) *redis.IntCmd {
+	keys = c.ks(append([]string{destKey}, keys...)...)
+	if err := c.checkSlots(keys...); err != nil {
+		return redis.NewIntResult(0, err)
+	}
+	return c.cmds.BitOpXor(keys[0], keys[1:]...)
+}
+func (c *prefixedCmdable) BitOpNot(destKey string, key string) *redis.IntCmd {
+	destKey, key = c.k(destKey), c.k(key)
+	if err := c.checkSlots(destKey, key); err != nil {
+		return redis.NewIntResult(0, err)
+	}
+	return c.cmds.BitOpNot(destKey, key)
+}
+
+// -------------- HyperLogLogger
+
+func (c *prefixedCmdable) PFAdd(key string, els ...interface{}) *redis.IntCmd {
+	return c.cmds.PFAdd(c.k(key), els...)
+}
+func (c *prefixedCmdable) PFCount(keys ...string) *redis.IntCmd {
+	keys = c.ks(keys...)
+	if err := c.checkSlots(keys...); err != nil {
+		return redis.NewIntResult(0, err)
+	}
+	return c.cmds.PFCount(keys...)
+}
+func (c *prefixedCmdable) PFMerge(dest string, keys ...string) *redis.StatusCmd {
+	keys = c.ks(append([]string{dest}, keys...)...)
+	if err := c.checkSlots(keys...); err != nil {
+		return redis.NewStatusResult("", err)
+	}
+	return c.cmds.PFMerge(keys[0], keys[1:]...)
+}
+
+// -------------- Geospatial
+
+func (c *prefixedCmdable) GeoAdd(key string, geoLocation ...*redis.GeoLocation) *redis.IntCmd {
+	return c.cmds.GeoAdd(c.k(key), geoLocation...)
+}
+func (c *prefixedCmdable) GeoRadius(key string, longitude, latitude float64, query *redis.GeoRadiusQuery) *redis.GeoLocationCmd {
+	key, query, err := c.kGeoQuery(key, query)
+	if err != nil {
+		return redis.NewGeoLocationCmdResult(nil, err)
+	}
+	return c.cmds.GeoRadius(key, longitude, latitude, query)
+}
+func (c *prefixedCmdable) GeoRadiusRO(key string, longitude, latitude float64, query *redis.GeoRadiusQuery) *redis.GeoLocationCmd {
+	key, query, err := c.kGeoQuery(key, query)
+	if err != nil {
+		return redis.NewGeoLocationCmdResult(nil, err)
+	}
+	return c.cmds.GeoRadiusRO(key, longitude, latitude, query)
+}
+func (c *prefixedCmdable) GeoRadiusByMember(key, member string, query *redis.GeoRadiusQuery) *redis.GeoLocationCmd {
+	key, query, err := c.kGeoQuery(key, query)
+	if err != nil {
+		return redis.NewGeoLocationCmdResult(nil, err)
+	}
+	return c.cmds.GeoRadiusByMember(key, member, query)
+}
+func (c *prefixedCmdable) GeoRadiusByMemberRO(key, member string, query *redis.GeoRadiusQuery) *redis.GeoLocationCmd {
+	key, query, err := c.kGeoQuery(key, query)
+	if err != nil {
+		return redis.NewGeoLocationCmdResult(nil, err)
+	}
+	return c.cmds.GeoRadiusByMemberRO(key, member, query)
+}
+func (c *prefixedCmdable) GeoDist(key string, member1, member2, unit string) *redis.FloatCmd {
+	return c.cmds.GeoDist(c.k(key), member1, member2, unit)
+}
+func (c *prefixedCmdable) GeoHash(key string, members ...string) *redis.StringSliceCmd {
+	return c.cmds.GeoHash(c.k(key), members...)
+}
+func (c *prefixedCmdable) GeoPos(key string, members ...string) *redis.GeoPosCmd {
+	return c.cmds.GeoPos(c.k(key), members...)
+}
+
+// kGeoQuery prefixes key and returns a copy of query with prefixed
+// Store and StoreDist keys, which must be in the slot of key
+func (c *prefixedCmdable) kGeoQuery(key string, query *redis.GeoRadiusQuery) (string, *redis.GeoRadiusQuery, error) {
+	key, query, err := c.client.kGeoQuery(key, query)
+	if err != nil && c.slotErr == nil {
+		c.slotErr = err
+	}
+	return key, query, err
+}
+
+// -------------- Scripter
+
+func (c *prefixedCmdable) Eval(script string, keys []string, args ...interface{}) *redis.Cmd {
+	keys = c.ks(keys...)
+	if err := c.checkSlots(keys...); err != nil {
+		return redis.NewCmdResult(nil, err)
+	}
+	return c.cmds.Eval(script, keys, args...)
+}
+func (c *prefixedCmdable) EvalSha(sha1 string, keys []string, args ...interface{}) *redis.Cmd {
+	keys = c.ks(keys...)
+	if err := c.checkSlots(keys...); err != nil {
+		return redis.NewCmdResult(nil, err)
+	}
+	return c.cmds.EvalSha(sha1, keys, args...)
+}
+func (c *prefixedCmdable) ScriptExists(hashes ...string) *redis.BoolSliceCmd {
+	return c.cmds.ScriptExists(hashes...)
+}
+func (c *prefixedCmdable) ScriptLoad(script string) *redis.StringCmd {
+	return c.cmds.ScriptLoad(script)
+}
+
+// -------------- KeyManager
+
+// keysScript returns the keys matching ARGV[1] without the prefix ARGV[2]
+const keysScript = `local keys = redis.call('KEYS', ARGV[1])
+for i, key in ipairs(keys) do
+	keys[i] = string.sub(key, #ARGV[2] + 1)
+end
+return keys`
+
+// randomKeyScript returns a random key having the prefix ARGV[1] without it,
+// like Client.RandomKey a SCAN of the prefix follows ARGV[2] missed attempts
+const randomKeyScript = `local prefix = ARGV[1]
+for i = 1, tonumber(ARGV[2]) do
+	local key = redis.call('RANDOMKEY')
+	if not key then
+		return false
+	end
+	if string.sub(key, 1, #prefix) == prefix then
+		return string.sub(key, #prefix + 1)
+	end
+end
+local cursor = '0'
+repeat
+	local reply = redis.call('SCAN', cursor, 'MATCH', prefix .. '*')
+	cursor = reply[1]
+	if #reply[2] > 0 then
+		return string.sub(reply[2][1], #prefix + 1)
+	end
+until cursor == '0'
+return false`
+
+// Keys queues KEYS, the keys are stripped from the prefix by a script. On a
+// cluster only the node chosen by the pipeline is queried
+func (c *prefixedCmdable) Keys(pattern string) *redis.StringSliceCmd {
+	prefix := c.k("")
+	if prefix == "" {
+		return c.cmds.Keys(pattern)
+	}
+	cmd := redis.NewStringSliceCmd("eval", keysScript, 0, c.k(pattern), prefix)
+	c.process(cmd)
+	return cmd
+}
+
+// RandomKey queues RANDOMKEY, the key is stripped from the prefix by a script
+func (c *prefixedCmdable) RandomKey() *redis.StringCmd {
+	prefix := c.k("")
+	if prefix == "" {
+		return c.cmds.RandomKey()
+	}
+	cmd := redis.NewStringCmd("eval", randomKeyScript, 0, prefix, randomKeyAttempts)
+	c.process(cmd)
+	return cmd
+}
+
+// process queues cmd
+func (c *prefixedCmdable) process(cmd redis.Cmder) {
+	c.cmds.Process(cmd)
+}
+func (c *prefixedCmdable) Rename(key, newkey string) *redis.StatusCmd {
+	key, newkey = c.k(key), c.k(newkey)
+	if err := c.checkSlots(key, newkey); err != nil {
+		return redis.NewStatusResult("", err)
+	}
+	return c.cmds.Rename(key, newkey)
+}
+func (c *prefixedCmdable) RenameNX(key, newkey string) *redis.BoolCmd {
+	key, newkey = c.k(key), c.k(newkey)
+	if err := c.checkSlots(key, newkey); err != nil {
+		return redis.NewBoolResult(false, err)
+	}
+	return c.cmds.RenameNX(key, newkey)
+}
+func (c *prefixedCmdable) Restore(key string, ttl time.Duration, value string) *redis.StatusCmd {
+	return c.cmds.Restore(c.k(key), ttl, value)
+}
+func (c *prefixedCmdable) RestoreReplace(key string, ttl time.Duration, value string) *redis.StatusCmd {
+	return c.cmds.RestoreReplace(c.k(key), ttl, value)
+}
+func (c *prefixedCmdable) Move(key string, db int64) *redis.BoolCmd {
+	return c.cmds.Move(c.k(key), db)
+}
+func (c *prefixedCmdable) ObjectRefCount(key string) *redis.IntCmd {
+	return c.cmds.ObjectRefCount(c.k(key))
+}
+func (c *prefixedCmdable) ObjectEncoding(key string) *redis.StringCmd {
+	return c.cmds.ObjectEncoding(c.k(key))
+}
+func (c *prefixedCmdable) ObjectIdleTime(key string) *redis.DurationCmd {
+	return c.cmds.ObjectIdleTime(c.k(key))
+}
+func (c *prefixedCmdable) Sort(key string, sort redis.Sort) *redis.StringSliceCmd {
+	key, sort = c.k(key), c.client.kSort(sort)
+	if sort.Store != "" {
+		if err := c.checkSlots(key, sort.Store); err != nil {
+			return redis.NewStringSliceResult(nil, err)
+		}
+	}
+	return c.cmds.Sort(key, sort)
+}
+func (c *prefixedCmdable) SortInterfaces(key string, sort redis.Sort) *redis.SliceCmd {
+	key, sort = c.k(key), c.client.kSort(sort)
+	if sort.Store != "" {
+		if err := c.checkSlots(key, sort.Store); err != nil {
+			return redis.NewSliceResult(nil, err)
+		}
+	}
+	return c.cmds.SortInterfaces(key, sort)
+}
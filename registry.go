@@ -0,0 +1,157 @@
+package redisClient
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/alauda/go-redis-client/util"
+	"github.com/spf13/viper"
+)
+
+// instanceKeys are the params whose suffix names an instance,
+// .e.g. REDIS_HOST_CACHE configures the instance named cache
+var instanceKeys = []string{"REDIS_HOST", "REDIS_URL"}
+
+// Registry holds one lazily created Client per named instance
+type Registry struct {
+	mu        sync.Mutex
+	viper     *viper.Viper
+	sources   *util.Sources
+	names     []string
+	instances map[string]*instance
+}
+
+// instance a named instance, its client is created outside the registry
+// lock so that a slow instance does not block the others
+type instance struct {
+	mu     sync.Mutex
+	client *Client
+}
+
+// NewRegistry merges configuration files and environment variables and
+// discovers the instance names, parameter priority: environment
+// variables > configuration file
+func NewRegistry() (*Registry, error) {
+	mixedViper, sources, err := util.LoadMixedParamsWithSources()
+	if err != nil {
+		return nil, err
+	}
+	return newRegistry(mixedViper, sources, os.Environ()), nil
+}
+
+// newRegistry discovers instance names from viper keys and environ
+func newRegistry(v *viper.Viper, sources *util.Sources, environ []string) *Registry {
+	found := map[string]bool{}
+	for _, key := range v.AllKeys() {
+		if name, ok := instanceName(strings.ToUpper(key)); ok {
+			found[name] = true
+		}
+	}
+	envPrefix := os.Getenv(util.EnvPrefixKey)
+	if envPrefix != "" {
+		envPrefix = strings.ToUpper(envPrefix) + "_"
+	}
+	for _, env := range environ {
+		key := strings.SplitN(env, "=", 2)[0]
+		if !strings.HasPrefix(key, envPrefix) {
+			continue
+		}
+		if name, ok := instanceName(strings.TrimPrefix(key, envPrefix)); ok {
+			found[name] = true
+		}
+	}
+	names := make([]string, 0, len(found))
+	for name := range found {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return &Registry{viper: v, sources: sources, names: names, instances: map[string]*instance{}}
+}
+
+// instanceName returns the lower case instance name of an instance key,
+// the default instance without suffix is named ""
+func instanceName(key string) (string, bool) {
+	key = strings.TrimSuffix(key, util.FileSuffix)
+	for _, base := range instanceKeys {
+		if key == base {
+			return "", true
+		}
+		if strings.HasPrefix(key, base+"_") && len(key) > len(base)+1 {
+			return strings.ToLower(key[len(base)+1:]), true
+		}
+	}
+	return "", false
+}
+
+// Names returns the discovered instance names
+func (reg *Registry) Names() []string {
+	return append([]string{}, reg.names...)
+}
+
+// Get returns the client of the named instance, it is created on first use
+// and creating it again is tried on the next call when it fails
+func (reg *Registry) Get(name string) (*Client, error) {
+	name = strings.ToLower(name)
+	inst, err := reg.instance(name)
+	if err != nil {
+		return nil, err
+	}
+	inst.mu.Lock()
+	defer inst.mu.Unlock()
+	if inst.client != nil {
+		return inst.client, nil
+	}
+	opts, err := customizedOptionLogged(reg.viper, RWType(strings.ToUpper(name)), reg.sources)
+	if err != nil {
+		return nil, err
+	}
+	client, err := NewClientE(*opts)
+	if err != nil {
+		return nil, fmt.Errorf("redis: instance %q: %v", name, err)
+	}
+	inst.client = client
+	return client, nil
+}
+
+// instance returns the instance of name, an error when it is not configured
+func (reg *Registry) instance(name string) (*instance, error) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	if inst, ok := reg.instances[name]; ok {
+		return inst, nil
+	}
+	known := false
+	for _, n := range reg.names {
+		known = known || n == name
+	}
+	if !known {
+		return nil, fmt.Errorf("redis: instance %q is not configured, known instances: %v", name, reg.names)
+	}
+	inst := &instance{}
+	reg.instances[name] = inst
+	return inst, nil
+}
+
+// Close closes every created client
+func (reg *Registry) Close() error {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	var errs []string
+	for name, inst := range reg.instances {
+		inst.mu.Lock()
+		if inst.client != nil {
+			if err := inst.client.Close(); err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %v", name, err))
+			}
+		}
+		inst.mu.Unlock()
+		delete(reg.instances, name)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("redis: close instances failed: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
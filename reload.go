@@ -0,0 +1,88 @@
+package redisClient
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/alauda/go-redis-client/util"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultDrainTimeout is the time to wait before closing a replaced connection pool
+const DefaultDrainTimeout = 10 * time.Second
+
+// ReloadOptions options to reload the client when the config changes
+type ReloadOptions struct {
+	// Interval to poll the config dir.
+	// Default is to use file system notifications.
+	PollInterval time.Duration
+	// Time to wait before closing the replaced connection pool,
+	// commands in flight use it until then.
+	// Default is 10 seconds.
+	DrainTimeout time.Duration
+	// Called after each reload with the new options, err is set when
+	// the reload failed and the previous connection is kept.
+	OnReload func(opts *Options, err error)
+}
+
+// Reload swaps the underlying connection for a new one built from opts,
+// the old connection pool is closed after drainTimeout. Namespaces follow
+// the reload of their root client and can not be reloaded themselves
+func (r *Client) Reload(opts Options, drainTimeout time.Duration) error {
+	if r.parent != nil {
+		return errors.New("redis: a namespace can not be reloaded, reload its root client")
+	}
+	fresh, err := NewClientE(opts)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	old := r.client
+	r.opts, r.client, r.fmtString, r.hashTag = fresh.opts, fresh.client, fresh.fmtString, fresh.hashTag
+	r.mu.Unlock()
+
+	if closer, ok := old.(io.Closer); ok {
+		time.AfterFunc(drainTimeout, func() {
+			if err := closer.Close(); err != nil {
+				logrus.Warnf("Close replaced redis client failed: %v", err)
+			}
+		})
+	}
+	return nil
+}
+
+// WatchConfig reloads the client with the mixed env and volume params of rwType
+// whenever the config file or a param file of CONFIG_DIR changes, call stop
+// to end watching. Environment variables are not watched
+func (r *Client) WatchConfig(rwType RWType, ro ReloadOptions) (stop func(), err error) {
+	if _, err := util.LoadMixedParams(); err != nil {
+		return nil, err
+	}
+	path := util.ConfigDir()
+	if ro.DrainTimeout <= 0 {
+		ro.DrainTimeout = DefaultDrainTimeout
+	}
+
+	done := make(chan struct{})
+	reload := func() {
+		opts, err := customizedOptionsFromFullVariable(rwType)
+		if err == nil {
+			err = r.Reload(*opts, ro.DrainTimeout)
+		}
+		if err != nil {
+			logrus.Errorf("Reload redis client from %s failed: %v", path, err)
+		} else {
+			logrus.Infof("Reload redis client from %s", path)
+		}
+		if ro.OnReload != nil {
+			ro.OnReload(opts, err)
+		}
+	}
+	if err := util.WatchDir(path, ro.PollInterval, done, reload); err != nil {
+		return nil, err
+	}
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }, nil
+}
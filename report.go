@@ -0,0 +1,195 @@
+package redisClient
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/alauda/go-redis-client/util"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// redacted replaces secret values in a ConfigReport
+const redacted = "******"
+
+// secretParams are the params whose raw value is redacted
+var secretParams = map[string]bool{
+	"REDIS_DB_PASSWORD": true,
+}
+
+// ConfigField describes where a field of the effective Options comes from
+type ConfigField struct {
+	// Field is the Options field name
+	Field string
+	// Key is the param name, .e.g. REDIS_HOST_READER
+	Key string
+	// Source is one of env, file, dir, config and default
+	Source string
+	// Location is the environment variable name or the file path
+	Location string
+	// Raw is the raw string value, secrets are redacted
+	Raw string
+}
+
+func (f ConfigField) String() string {
+	location := f.Source
+	if f.Location != "" {
+		location += ":" + f.Location
+	}
+	return fmt.Sprintf("%s=%q (%s from %s)", f.Field, f.Raw, f.Key, location)
+}
+
+// ConfigReport the effective Options with the provenance of each field
+type ConfigReport struct {
+	// Options are the resolved options, Password is redacted
+	Options Options
+	Fields  []ConfigField
+}
+
+func (c *ConfigReport) String() string {
+	lines := make([]string, len(c.Fields))
+	for i, field := range c.Fields {
+		lines[i] = field.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Log logs every field of the report
+func (c *ConfigReport) Log() {
+	logrus.Infof("Effective redis configuration, type:%s hosts:%v", c.Options.Type, c.Options.Hosts)
+	for _, field := range c.Fields {
+		logrus.Infof("  %s", field)
+	}
+}
+
+// EffectiveConfig merges configuration files and environment variables as
+// AutoConfigRedisClient does and reports where each field comes from
+func EffectiveConfig(rwType RWType) (*ConfigReport, error) {
+	mixedViper, sources, err := util.LoadMixedParamsWithSources()
+	if err != nil {
+		return nil, err
+	}
+	_, report, err := customizedOptionReport(mixedViper, rwType, sources)
+	return report, err
+}
+
+// paramReader reads the params of rwType from viper and records where
+// each of them comes from
+type paramReader struct {
+	viper   *viper.Viper
+	rwType  RWType
+	sources *util.Sources
+	fields  []ConfigField
+}
+
+// key returns the param name with the rwType suffix
+func (p *paramReader) key(name string) string {
+	return p.rwType.FmtSuffix(name)
+}
+
+func (p *paramReader) getString(field, name string) string {
+	p.record(field, name)
+	return p.viper.GetString(p.key(name))
+}
+
+func (p *paramReader) getStringSlice(field, name string) []string {
+	p.record(field, name)
+	return p.viper.GetStringSlice(p.key(name))
+}
+
+func (p *paramReader) getInt(field, name string) int {
+	p.record(field, name)
+	return p.viper.GetInt(p.key(name))
+}
+
+func (p *paramReader) getBool(field, name string) bool {
+	p.record(field, name)
+	return p.viper.GetBool(p.key(name))
+}
+
+// getDuration reads a duration like 250ms or 5, integers are seconds,
+// fallbacks are read in order when name is not set
+func (p *paramReader) getDuration(field, name string, fallbacks ...string) (time.Duration, error) {
+	name = p.lookup(name, fallbacks...)
+	p.record(field, name)
+	value := p.viper.GetString(p.key(name))
+	if value == "" {
+		return 0, nil
+	}
+	duration, err := parseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("redis: invalid duration %s=%q: %v", p.key(name), value, err)
+	}
+	return duration, nil
+}
+
+// lookup returns the first param of name and fallbacks which is set
+func (p *paramReader) lookup(name string, fallbacks ...string) string {
+	for _, n := range append([]string{name}, fallbacks...) {
+		if p.viper.IsSet(p.key(n)) {
+			return n
+		}
+	}
+	return name
+}
+
+// record records that field is read from the param name
+func (p *paramReader) record(field, name string) {
+	if p.sources == nil {
+		return
+	}
+	key := p.key(name)
+	source, location := p.sources.Origin(p.viper, key)
+	raw := ""
+	if value := p.viper.Get(key); value != nil {
+		raw = fmt.Sprint(value)
+		if _, ok := value.(string); !ok {
+			if slice := p.viper.GetStringSlice(key); len(slice) > 0 {
+				raw = strings.Join(slice, ",")
+			}
+		}
+	}
+	switch {
+	case raw == "":
+	case secretParams[name]:
+		raw = redacted
+	case name == "REDIS_URL":
+		raw = redactURL(raw)
+	}
+	p.fields = append(p.fields, ConfigField{Field: field, Key: key, Source: source, Location: location, Raw: raw})
+}
+
+// report returns the report of opt, nil when sources are not recorded
+func (p *paramReader) report(opt *Options) *ConfigReport {
+	if p.sources == nil {
+		return nil
+	}
+	report := &ConfigReport{Options: *opt, Fields: p.fields}
+	if report.Options.Password != "" {
+		report.Options.Password = redacted
+	}
+	return report
+}
+
+// redactURL redacts the password of a connection URL
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return redacted
+	}
+	if u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), redacted)
+		} else {
+			// redis://password@host
+			u.User = url.User(redacted)
+		}
+	}
+	if query := u.Query(); query.Get("password") != "" {
+		query.Set("password", redacted)
+		u.RawQuery = query.Encode()
+	}
+	return u.String()
+}
@@ -0,0 +1,755 @@
+package redisClient
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// RWClient routes read-only commands to Reader and the other commands
+// to Writer, it implements Commander
+type RWClient struct {
+	Reader Commander
+	Writer Commander
+	// FallbackToWriter retries read-only commands on Writer when
+	// they fail on Reader, redis.Nil is not a failure. Scans are never
+	// retried as a cursor is only valid on the node which returned it
+	FallbackToWriter bool
+}
+
+var _ Commander = (*RWClient)(nil)
+
+// NewRWClient creates a RWClient from a reader and a writer client
+func NewRWClient(reader, writer Commander) *RWClient {
+	return &RWClient{Reader: reader, Writer: writer}
+}
+
+// AutoConfigRWClient merges configuration files and environment variables
+// to create the READER and WRITER clients of a RWClient
+func AutoConfigRWClient() (*RWClient, error) {
+	reader, err := AutoConfigRedisClient(OnlyRead)
+	if err != nil {
+		return nil, err
+	}
+	writer, err := AutoConfigRedisClient(OnlyWrite)
+	if err != nil {
+		reader.Close()
+		return nil, err
+	}
+	return NewRWClient(reader, writer), nil
+}
+
+// ReadFromWriter returns the writer, use it to read your own writes
+func (c *RWClient) ReadFromWriter() Commander {
+	return c.Writer
+}
+
+// Close closes both reader and writer
+func (c *RWClient) Close() error {
+	var errs []error
+	for _, cmd := range []Commander{c.Reader, c.Writer} {
+		if closer, ok := cmd.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("redis: close rw client failed: %v", errs)
+	}
+	return nil
+}
+
+// failed will return whether a reader command should be retried on writer
+func (c *RWClient) failed(cmd redis.Cmder) bool {
+	return c.FallbackToWriter && cmd.Err() != nil && cmd.Err() != redis.Nil
+}
+
+// -------------- Pinger
+
+func (c *RWClient) Ping() *redis.StatusCmd {
+	return c.Writer.Ping()
+}
+
+// -------------- Incrementer
+
+func (c *RWClient) Incr(key string) *redis.IntCmd {
+	return c.Writer.Incr(key)
+}
+func (c *RWClient) IncrBy(key string, value int64) *redis.IntCmd {
+	return c.Writer.IncrBy(key, value)
+}
+func (c *RWClient) IncrByFloat(key string, value float64) *redis.FloatCmd {
+	return c.Writer.IncrByFloat(key, value)
+}
+
+// -------------- Decremeter
+
+func (c *RWClient) Decr(key string) *redis.IntCmd {
+	return c.Writer.Decr(key)
+}
+func (c *RWClient) DecrBy(key string, value int64) *redis.IntCmd {
+	return c.Writer.DecrBy(key, value)
+}
+
+// -------------- Expirer
+
+func (c *RWClient) Expire(key string, expiration time.Duration) *redis.BoolCmd {
+	return c.Writer.Expire(key, expiration)
+}
+func (c *RWClient) ExpireAt(key string, tm time.Time) *redis.BoolCmd {
+	return c.Writer.ExpireAt(key, tm)
+}
+func (c *RWClient) Persist(key string) *redis.BoolCmd {
+	return c.Writer.Persist(key)
+}
+func (c *RWClient) PExpire(key string, expiration time.Duration) *redis.BoolCmd {
+	return c.Writer.PExpire(key, expiration)
+}
+func (c *RWClient) PExpireAt(key string, tm time.Time) *redis.BoolCmd {
+	return c.Writer.PExpireAt(key, tm)
+}
+func (c *RWClient) PTTL(key string) *redis.DurationCmd {
+	if cmd := c.Reader.PTTL(key); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.PTTL(key)
+}
+func (c *RWClient) TTL(key string) *redis.DurationCmd {
+	if cmd := c.Reader.TTL(key); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.TTL(key)
+}
+
+// -------------- Getter
+
+func (c *RWClient) Exists(keys ...string) *redis.IntCmd {
+	if cmd := c.Reader.Exists(keys...); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.Exists(keys...)
+}
+func (c *RWClient) Get(key string) *redis.StringCmd {
+	if cmd := c.Reader.Get(key); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.Get(key)
+}
+func (c *RWClient) GetBit(key string, offset int64) *redis.IntCmd {
+	if cmd := c.Reader.GetBit(key, offset); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.GetBit(key, offset)
+}
+func (c *RWClient) GetRange(key string, start, end int64) *redis.StringCmd {
+	if cmd := c.Reader.GetRange(key, start, end); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.GetRange(key, start, end)
+}
+func (c *RWClient) GetSet(key string, value interface{}) *redis.StringCmd {
+	return c.Writer.GetSet(key, value)
+}
+func (c *RWClient) MGet(keys ...string) *redis.SliceCmd {
+	if cmd := c.Reader.MGet(keys...); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.MGet(keys...)
+}
+func (c *RWClient) Dump(key string) *redis.StringCmd {
+	if cmd := c.Reader.Dump(key); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.Dump(key)
+}
+func (c *RWClient) StrLen(key string) *redis.IntCmd {
+	if cmd := c.Reader.StrLen(key); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.StrLen(key)
+}
+
+// -------------- KeyManager
+
+func (c *RWClient) Keys(pattern string) *redis.StringSliceCmd {
+	if cmd := c.Reader.Keys(pattern); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.Keys(pattern)
+}
+func (c *RWClient) RandomKey() *redis.StringCmd {
+	if cmd := c.Reader.RandomKey(); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.RandomKey()
+}
+func (c *RWClient) Rename(key, newkey string) *redis.StatusCmd {
+	return c.Writer.Rename(key, newkey)
+}
+func (c *RWClient) RenameNX(key, newkey string) *redis.BoolCmd {
+	return c.Writer.RenameNX(key, newkey)
+}
+func (c *RWClient) Restore(key string, ttl time.Duration, value string) *redis.StatusCmd {
+	return c.Writer.Restore(key, ttl, value)
+}
+func (c *RWClient) RestoreReplace(key string, ttl time.Duration, value string) *redis.StatusCmd {
+	return c.Writer.RestoreReplace(key, ttl, value)
+}
+func (c *RWClient) Move(key string, db int64) *redis.BoolCmd {
+	return c.Writer.Move(key, db)
+}
+func (c *RWClient) ObjectRefCount(key string) *redis.IntCmd {
+	if cmd := c.Reader.ObjectRefCount(key); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.ObjectRefCount(key)
+}
+func (c *RWClient) ObjectEncoding(key string) *redis.StringCmd {
+	if cmd := c.Reader.ObjectEncoding(key); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.ObjectEncoding(key)
+}
+func (c *RWClient) ObjectIdleTime(key string) *redis.DurationCmd {
+	if cmd := c.Reader.ObjectIdleTime(key); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.ObjectIdleTime(key)
+}
+func (c *RWClient) Sort(key string, sort redis.Sort) *redis.StringSliceCmd {
+	return c.Writer.Sort(key, sort)
+}
+func (c *RWClient) SortInterfaces(key string, sort redis.Sort) *redis.SliceCmd {
+	return c.Writer.SortInterfaces(key, sort)
+}
+
+// -------------- Hasher
+
+func (c *RWClient) HExists(key, field string) *redis.BoolCmd {
+	if cmd := c.Reader.HExists(key, field); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.HExists(key, field)
+}
+func (c *RWClient) HGet(key, field string) *redis.StringCmd {
+	if cmd := c.Reader.HGet(key, field); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.HGet(key, field)
+}
+func (c *RWClient) HGetAll(key string) *redis.StringStringMapCmd {
+	if cmd := c.Reader.HGetAll(key); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.HGetAll(key)
+}
+func (c *RWClient) HIncrBy(key, field string, incr int64) *redis.IntCmd {
+	return c.Writer.HIncrBy(key, field, incr)
+}
+func (c *RWClient) HIncrByFloat(key, field string, incr float64) *redis.FloatCmd {
+	return c.Writer.HIncrByFloat(key, field, incr)
+}
+func (c *RWClient) HKeys(key string) *redis.StringSliceCmd {
+	if cmd := c.Reader.HKeys(key); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.HKeys(key)
+}
+func (c *RWClient) HLen(key string) *redis.IntCmd {
+	if cmd := c.Reader.HLen(key); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.HLen(key)
+}
+func (c *RWClient) HMGet(key string, fields ...string) *redis.SliceCmd {
+	if cmd := c.Reader.HMGet(key, fields...); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.HMGet(key, fields...)
+}
+func (c *RWClient) HMSet(key string, fields map[string]interface{}) *redis.StatusCmd {
+	return c.Writer.HMSet(key, fields)
+}
+func (c *RWClient) HSet(key, field string, value interface{}) *redis.BoolCmd {
+	return c.Writer.HSet(key, field, value)
+}
+func (c *RWClient) HSetNX(key, field string, value interface{}) *redis.BoolCmd {
+	return c.Writer.HSetNX(key, field, value)
+}
+func (c *RWClient) HVals(key string) *redis.StringSliceCmd {
+	if cmd := c.Reader.HVals(key); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.HVals(key)
+}
+func (c *RWClient) HDel(key string, fields ...string) *redis.IntCmd {
+	return c.Writer.HDel(key, fields...)
+}
+
+// -------------- Lister
+
+func (c *RWClient) LIndex(key string, index int64) *redis.StringCmd {
+	if cmd := c.Reader.LIndex(key, index); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.LIndex(key, index)
+}
+func (c *RWClient) LInsert(key, op string, pivot, value interface{}) *redis.IntCmd {
+	return c.Writer.LInsert(key, op, pivot, value)
+}
+func (c *RWClient) LInsertAfter(key string, pivot, value interface{}) *redis.IntCmd {
+	return c.Writer.LInsertAfter(key, pivot, value)
+}
+func (c *RWClient) LInsertBefore(key string, pivot, value interface{}) *redis.IntCmd {
+	return c.Writer.LInsertBefore(key, pivot, value)
+}
+func (c *RWClient) LLen(key string) *redis.IntCmd {
+	if cmd := c.Reader.LLen(key); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.LLen(key)
+}
+func (c *RWClient) LPop(key string) *redis.StringCmd {
+	return c.Writer.LPop(key)
+}
+func (c *RWClient) LPush(key string, values ...interface{}) *redis.IntCmd {
+	return c.Writer.LPush(key, values...)
+}
+func (c *RWClient) LPushX(key string, value interface{}) *redis.IntCmd {
+	return c.Writer.LPushX(key, value)
+}
+func (c *RWClient) LRange(key string, start, stop int64) *redis.StringSliceCmd {
+	if cmd := c.Reader.LRange(key, start, stop); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.LRange(key, start, stop)
+}
+func (c *RWClient) LRem(key string, count int64, value interface{}) *redis.IntCmd {
+	return c.Writer.LRem(key, count, value)
+}
+func (c *RWClient) LSet(key string, index int64, value interface{}) *redis.StatusCmd {
+	return c.Writer.LSet(key, index, value)
+}
+func (c *RWClient) LTrim(key string, start, stop int64) *redis.StatusCmd {
+	return c.Writer.LTrim(key, start, stop)
+}
+func (c *RWClient) RPop(key string) *redis.StringCmd {
+	return c.Writer.RPop(key)
+}
+func (c *RWClient) RPopLPush(source, destination string) *redis.StringCmd {
+	return c.Writer.RPopLPush(source, destination)
+}
+func (c *RWClient) RPush(key string, values ...interface{}) *redis.IntCmd {
+	return c.Writer.RPush(key, values...)
+}
+func (c *RWClient) RPushX(key string, value interface{}) *redis.IntCmd {
+	return c.Writer.RPushX(key, value)
+}
+
+// -------------- Setter
+
+func (c *RWClient) Set(key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
+	return c.Writer.Set(key, value, expiration)
+}
+func (c *RWClient) Append(key, value string) *redis.IntCmd {
+	return c.Writer.Append(key, value)
+}
+func (c *RWClient) Del(keys ...string) *redis.IntCmd {
+	return c.Writer.Del(keys...)
+}
+func (c *RWClient) Unlink(keys ...string) *redis.IntCmd {
+	return c.Writer.Unlink(keys...)
+}
+func (c *RWClient) SetNX(key string, value interface{}, expiration time.Duration) *redis.BoolCmd {
+	return c.Writer.SetNX(key, value, expiration)
+}
+func (c *RWClient) SetXX(key string, value interface{}, expiration time.Duration) *redis.BoolCmd {
+	return c.Writer.SetXX(key, value, expiration)
+}
+func (c *RWClient) SetRange(key string, offset int64, value string) *redis.IntCmd {
+	return c.Writer.SetRange(key, offset, value)
+}
+func (c *RWClient) MSet(pairs ...interface{}) *redis.StatusCmd {
+	return c.Writer.MSet(pairs...)
+}
+func (c *RWClient) MSetNX(pairs ...interface{}) *redis.BoolCmd {
+	return c.Writer.MSetNX(pairs...)
+}
+
+// -------------- Bitmapper
+
+func (c *RWClient) SetBit(key string, offset int64, value int) *redis.IntCmd {
+	return c.Writer.SetBit(key, offset, value)
+}
+func (c *RWClient) BitCount(key string, bitCount *redis.BitCount) *redis.IntCmd {
+	if cmd := c.Reader.BitCount(key, bitCount); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.BitCount(key, bitCount)
+}
+func (c *RWClient) BitPos(key string, bit int64, pos ...int64) *redis.IntCmd {
+	if cmd := c.Reader.BitPos(key, bit, pos...); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.BitPos(key, bit, pos...)
+}
+func (c *RWClient) BitOpAnd(destKey string, keys ...string) *redis.IntCmd {
+	return c.Writer.BitOpAnd(destKey, keys...)
+}
+func (c *RWClient) BitOpOr(destKey string, keys ...string) *redis.IntCmd {
+	return c.Writer.BitOpOr(destKey, keys...)
+}
+func (c *RWClient) BitOpXor(destKey string, keys ...string) *redis.IntCmd {
+	return c.Writer.BitOpXor(destKey, keys...)
+}
+func (c *RWClient) BitOpNot(destKey string, key string) *redis.IntCmd {
+	return c.Writer.BitOpNot(destKey, key)
+}
+
+// -------------- Settable
+
+func (c *RWClient) SAdd(key string, members ...interface{}) *redis.IntCmd {
+	return c.Writer.SAdd(key, members...)
+}
+func (c *RWClient) SCard(key string) *redis.IntCmd {
+	if cmd := c.Reader.SCard(key); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.SCard(key)
+}
+func (c *RWClient) SDiff(keys ...string) *redis.StringSliceCmd {
+	if cmd := c.Reader.SDiff(keys...); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.SDiff(keys...)
+}
+func (c *RWClient) SDiffStore(destination string, keys ...string) *redis.IntCmd {
+	return c.Writer.SDiffStore(destination, keys...)
+}
+func (c *RWClient) SInter(keys ...string) *redis.StringSliceCmd {
+	if cmd := c.Reader.SInter(keys...); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.SInter(keys...)
+}
+func (c *RWClient) SInterStore(destination string, keys ...string) *redis.IntCmd {
+	return c.Writer.SInterStore(destination, keys...)
+}
+func (c *RWClient) SIsMember(key string, member interface{}) *redis.BoolCmd {
+	if cmd := c.Reader.SIsMember(key, member); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.SIsMember(key, member)
+}
+func (c *RWClient) SMembers(key string) *redis.StringSliceCmd {
+	if cmd := c.Reader.SMembers(key); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.SMembers(key)
+}
+func (c *RWClient) SMove(source, destination string, member interface{}) *redis.BoolCmd {
+	return c.Writer.SMove(source, destination, member)
+}
+func (c *RWClient) SPop(key string) *redis.StringCmd {
+	return c.Writer.SPop(key)
+}
+func (c *RWClient) SPopN(key string, count int64) *redis.StringSliceCmd {
+	return c.Writer.SPopN(key, count)
+}
+func (c *RWClient) SRandMember(key string) *redis.StringCmd {
+	if cmd := c.Reader.SRandMember(key); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.SRandMember(key)
+}
+func (c *RWClient) SRandMemberN(key string, count int64) *redis.StringSliceCmd {
+	if cmd := c.Reader.SRandMemberN(key, count); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.SRandMemberN(key, count)
+}
+func (c *RWClient) SRem(key string, members ...interface{}) *redis.IntCmd {
+	return c.Writer.SRem(key, members...)
+}
+func (c *RWClient) SUnion(keys ...string) *redis.StringSliceCmd {
+	if cmd := c.Reader.SUnion(keys...); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.SUnion(keys...)
+}
+func (c *RWClient) SUnionStore(destination string, keys ...string) *redis.IntCmd {
+	return c.Writer.SUnionStore(destination, keys...)
+}
+
+// -------------- SortedSettable
+
+func (c *RWClient) ZAdd(key string, members ...redis.Z) *redis.IntCmd {
+	return c.Writer.ZAdd(key, members...)
+}
+func (c *RWClient) ZAddNX(key string, members ...redis.Z) *redis.IntCmd {
+	return c.Writer.ZAddNX(key, members...)
+}
+func (c *RWClient) ZAddXX(key string, members ...redis.Z) *redis.IntCmd {
+	return c.Writer.ZAddXX(key, members...)
+}
+func (c *RWClient) ZAddCh(key string, members ...redis.Z) *redis.IntCmd {
+	return c.Writer.ZAddCh(key, members...)
+}
+func (c *RWClient) ZAddNXCh(key string, members ...redis.Z) *redis.IntCmd {
+	return c.Writer.ZAddNXCh(key, members...)
+}
+func (c *RWClient) ZAddXXCh(key string, members ...redis.Z) *redis.IntCmd {
+	return c.Writer.ZAddXXCh(key, members...)
+}
+func (c *RWClient) ZIncr(key string, member redis.Z) *redis.FloatCmd {
+	return c.Writer.ZIncr(key, member)
+}
+func (c *RWClient) ZIncrNX(key string, member redis.Z) *redis.FloatCmd {
+	return c.Writer.ZIncrNX(key, member)
+}
+func (c *RWClient) ZIncrXX(key string, member redis.Z) *redis.FloatCmd {
+	return c.Writer.ZIncrXX(key, member)
+}
+func (c *RWClient) ZCard(key string) *redis.IntCmd {
+	if cmd := c.Reader.ZCard(key); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.ZCard(key)
+}
+func (c *RWClient) ZCount(key, min, max string) *redis.IntCmd {
+	if cmd := c.Reader.ZCount(key, min, max); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.ZCount(key, min, max)
+}
+func (c *RWClient) ZIncrBy(key string, increment float64, member string) *redis.FloatCmd {
+	return c.Writer.ZIncrBy(key, increment, member)
+}
+func (c *RWClient) ZInterStore(destination string, store redis.ZStore, keys ...string) *redis.IntCmd {
+	return c.Writer.ZInterStore(destination, store, keys...)
+}
+func (c *RWClient) ZRange(key string, start, stop int64) *redis.StringSliceCmd {
+	if cmd := c.Reader.ZRange(key, start, stop); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.ZRange(key, start, stop)
+}
+func (c *RWClient) ZRangeWithScores(key string, start, stop int64) *redis.ZSliceCmd {
+	if cmd := c.Reader.ZRangeWithScores(key, start, stop); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.ZRangeWithScores(key, start, stop)
+}
+func (c *RWClient) ZRangeByScore(key string, opt redis.ZRangeBy) *redis.StringSliceCmd {
+	if cmd := c.Reader.ZRangeByScore(key, opt); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.ZRangeByScore(key, opt)
+}
+func (c *RWClient) ZRangeByLex(key string, opt redis.ZRangeBy) *redis.StringSliceCmd {
+	if cmd := c.Reader.ZRangeByLex(key, opt); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.ZRangeByLex(key, opt)
+}
+func (c *RWClient) ZRangeByScoreWithScores(key string, opt redis.ZRangeBy) *redis.ZSliceCmd {
+	if cmd := c.Reader.ZRangeByScoreWithScores(key, opt); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.ZRangeByScoreWithScores(key, opt)
+}
+func (c *RWClient) ZRank(key, member string) *redis.IntCmd {
+	if cmd := c.Reader.ZRank(key, member); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.ZRank(key, member)
+}
+func (c *RWClient) ZRem(key string, members ...interface{}) *redis.IntCmd {
+	return c.Writer.ZRem(key, members...)
+}
+func (c *RWClient) ZRemRangeByRank(key string, start, stop int64) *redis.IntCmd {
+	return c.Writer.ZRemRangeByRank(key, start, stop)
+}
+func (c *RWClient) ZRemRangeByScore(key, min, max string) *redis.IntCmd {
+	return c.Writer.ZRemRangeByScore(key, min, max)
+}
+func (c *RWClient) ZRemRangeByLex(key, min, max string) *redis.IntCmd {
+	return c.Writer.ZRemRangeByLex(key, min, max)
+}
+func (c *RWClient) ZRevRange(key string, start, stop int64) *redis.StringSliceCmd {
+	if cmd := c.Reader.ZRevRange(key, start, stop); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.ZRevRange(key, start, stop)
+}
+func (c *RWClient) ZRevRangeWithScores(key string, start, stop int64) *redis.ZSliceCmd {
+	if cmd := c.Reader.ZRevRangeWithScores(key, start, stop); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.ZRevRangeWithScores(key, start, stop)
+}
+func (c *RWClient) ZRevRangeByScore(key string, opt redis.ZRangeBy) *redis.StringSliceCmd {
+	if cmd := c.Reader.ZRevRangeByScore(key, opt); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.ZRevRangeByScore(key, opt)
+}
+func (c *RWClient) ZRevRangeByLex(key string, opt redis.ZRangeBy) *redis.StringSliceCmd {
+	if cmd := c.Reader.ZRevRangeByLex(key, opt); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.ZRevRangeByLex(key, opt)
+}
+func (c *RWClient) ZRevRangeByScoreWithScores(key string, opt redis.ZRangeBy) *redis.ZSliceCmd {
+	if cmd := c.Reader.ZRevRangeByScoreWithScores(key, opt); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.ZRevRangeByScoreWithScores(key, opt)
+}
+func (c *RWClient) ZRevRank(key, member string) *redis.IntCmd {
+	if cmd := c.Reader.ZRevRank(key, member); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.ZRevRank(key, member)
+}
+func (c *RWClient) ZScore(key, member string) *redis.FloatCmd {
+	if cmd := c.Reader.ZScore(key, member); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.ZScore(key, member)
+}
+func (c *RWClient) ZUnionStore(dest string, store redis.ZStore, keys ...string) *redis.IntCmd {
+	return c.Writer.ZUnionStore(dest, store, keys...)
+}
+
+// -------------- BlockedSettable
+
+func (c *RWClient) BLPop(timeout time.Duration, keys ...string) *redis.StringSliceCmd {
+	return c.Writer.BLPop(timeout, keys...)
+}
+func (c *RWClient) BRPop(timeout time.Duration, keys ...string) *redis.StringSliceCmd {
+	return c.Writer.BRPop(timeout, keys...)
+}
+func (c *RWClient) BRPopLPush(source, destination string, timeout time.Duration) *redis.StringCmd {
+	return c.Writer.BRPopLPush(source, destination, timeout)
+}
+
+// -------------- HyperLogLogger
+
+func (c *RWClient) PFAdd(key string, els ...interface{}) *redis.IntCmd {
+	return c.Writer.PFAdd(key, els...)
+}
+func (c *RWClient) PFCount(keys ...string) *redis.IntCmd {
+	if cmd := c.Reader.PFCount(keys...); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.PFCount(keys...)
+}
+func (c *RWClient) PFMerge(dest string, keys ...string) *redis.StatusCmd {
+	return c.Writer.PFMerge(dest, keys...)
+}
+
+// -------------- Geospatial
+
+func (c *RWClient) GeoAdd(key string, geoLocation ...*redis.GeoLocation) *redis.IntCmd {
+	return c.Writer.GeoAdd(key, geoLocation...)
+}
+func (c *RWClient) GeoRadius(key string, longitude, latitude float64, query *redis.GeoRadiusQuery) *redis.GeoLocationCmd {
+	return c.Writer.GeoRadius(key, longitude, latitude, query)
+}
+func (c *RWClient) GeoRadiusRO(key string, longitude, latitude float64, query *redis.GeoRadiusQuery) *redis.GeoLocationCmd {
+	if cmd := c.Reader.GeoRadiusRO(key, longitude, latitude, query); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.GeoRadiusRO(key, longitude, latitude, query)
+}
+func (c *RWClient) GeoRadiusByMember(key, member string, query *redis.GeoRadiusQuery) *redis.GeoLocationCmd {
+	return c.Writer.GeoRadiusByMember(key, member, query)
+}
+func (c *RWClient) GeoRadiusByMemberRO(key, member string, query *redis.GeoRadiusQuery) *redis.GeoLocationCmd {
+	if cmd := c.Reader.GeoRadiusByMemberRO(key, member, query); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.GeoRadiusByMemberRO(key, member, query)
+}
+func (c *RWClient) GeoDist(key string, member1, member2, unit string) *redis.FloatCmd {
+	if cmd := c.Reader.GeoDist(key, member1, member2, unit); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.GeoDist(key, member1, member2, unit)
+}
+func (c *RWClient) GeoHash(key string, members ...string) *redis.StringSliceCmd {
+	if cmd := c.Reader.GeoHash(key, members...); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.GeoHash(key, members...)
+}
+func (c *RWClient) GeoPos(key string, members ...string) *redis.GeoPosCmd {
+	if cmd := c.Reader.GeoPos(key, members...); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.GeoPos(key, members...)
+}
+
+// -------------- Scripter
+
+func (c *RWClient) Eval(script string, keys []string, args ...interface{}) *redis.Cmd {
+	return c.Writer.Eval(script, keys, args...)
+}
+func (c *RWClient) EvalSha(sha1 string, keys []string, args ...interface{}) *redis.Cmd {
+	return c.Writer.EvalSha(sha1, keys, args...)
+}
+func (c *RWClient) ScriptExists(hashes ...string) *redis.BoolSliceCmd {
+	return c.Writer.ScriptExists(hashes...)
+}
+func (c *RWClient) ScriptLoad(script string) *redis.StringCmd {
+	return c.Writer.ScriptLoad(script)
+}
+
+// -------------- Scanner
+
+func (c *RWClient) Type(key string) *redis.StatusCmd {
+	if cmd := c.Reader.Type(key); !c.failed(cmd) {
+		return cmd
+	}
+	return c.Writer.Type(key)
+}
+
+// Scan and the other scans never fall back to Writer, the next pages would
+// be read from Reader with a cursor of Writer
+func (c *RWClient) Scan(cursor uint64, match string, count int64) *redis.ScanCmd {
+	return c.Reader.Scan(cursor, match, count)
+}
+func (c *RWClient) SScan(key string, cursor uint64, match string, count int64) *redis.ScanCmd {
+	return c.Reader.SScan(key, cursor, match, count)
+}
+func (c *RWClient) HScan(key string, cursor uint64, match string, count int64) *redis.ScanCmd {
+	return c.Reader.HScan(key, cursor, match, count)
+}
+func (c *RWClient) ZScan(key string, cursor uint64, match string, count int64) *redis.ScanCmd {
+	return c.Reader.ZScan(key, cursor, match, count)
+}
+
+// -------------- Publisher
+
+func (c *RWClient) Publish(channel string, message interface{}) *redis.IntCmd {
+	return c.Writer.Publish(channel, message)
+}
+
+// -------------- Subscriber
+
+func (c *RWClient) Subscribe(channels ...string) *redis.PubSub {
+	return c.Writer.Subscribe(channels...)
+}
+
+// -------------- Pipeline
+
+func (c *RWClient) Pipeline() redis.Pipeliner {
+	return c.Writer.Pipeline()
+}
+func (c *RWClient) TxPipeline() redis.Pipeliner {
+	return c.Writer.TxPipeline()
+}
@@ -0,0 +1,70 @@
+package redisClient_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	redis "github.com/alauda/go-redis-client"
+)
+
+func TestRWClient(t *testing.T) {
+	reader := newFakeServer(t, "tcp", "127.0.0.1:0", func(args []string) string {
+		if len(args) > 1 && args[1] == "missing" {
+			return "$-1\r\n"
+		}
+		// two pages of keys matching page*
+		if strings.ToLower(args[0]) == "scan" && args[3] == "page*" {
+			if args[1] == "0" {
+				return "*2\r\n$1\r\n7\r\n*1\r\n$5\r\npage1\r\n"
+			}
+			return "*2\r\n$1\r\n0\r\n*1\r\n$5\r\npage2\r\n"
+		}
+		return "-ERR reader is down\r\n"
+	})
+	defer reader.Close()
+	writer := newFakeServer(t, "tcp", "127.0.0.1:0", func(args []string) string {
+		switch strings.ToLower(args[0]) {
+		case "get":
+			return "$5\r\nvalue\r\n"
+		case "scan":
+			return "*2\r\n$1\r\n0\r\n*1\r\n$3\r\nkey\r\n"
+		}
+		return "+OK\r\n"
+	})
+	defer writer.Close()
+	client := redis.NewRWClient(
+		redis.NewClient(redis.Options{Type: redis.ClientNormal, Hosts: []string{reader.Addr()}}),
+		redis.NewClient(redis.Options{Type: redis.ClientNormal, Hosts: []string{writer.Addr()}}),
+	)
+	defer client.Close()
+
+	if err := client.Set("a", "value", 0).Err(); err != nil || !writer.received("set a") || reader.received("set") {
+		t.Error("writes should go to the writer:", err)
+	}
+	if err := client.Get("b").Err(); err == nil || !reader.received("get b") || writer.received("get b") {
+		t.Error("reads should go to the reader and fail without fallback, got:", err)
+	}
+	if res := client.ReadFromWriter().Get("c").Val(); res != "value" || reader.received("get c") {
+		t.Error("ReadFromWriter should read from the writer, got:", res)
+	}
+
+	client.FallbackToWriter = true
+	if res := client.Get("d").Val(); res != "value" || !reader.received("get d") || !writer.received("get d") {
+		t.Error("failed reads should fall back to the writer, got:", res)
+	}
+	if err := client.Get("missing").Err(); err != redis.RedisNil || writer.received("get missing") {
+		t.Error("nil replies should not fall back to the writer, got:", err)
+	}
+	if err := client.Scan(0, "*", 10).Err(); err == nil || writer.received("scan") {
+		t.Error("scans should not fall back to the writer, got:", err)
+	}
+	var keys []string
+	it := client.Scan(0, "page*", 1).Iterator()
+	for it.Next() {
+		keys = append(keys, it.Val())
+	}
+	if err := it.Err(); err != nil || !reflect.DeepEqual(keys, []string{"page1", "page2"}) || writer.received("scan") {
+		t.Error("every page should be read from the reader, got:", keys, err)
+	}
+}
@@ -0,0 +1,168 @@
+package redisClient
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-redis/redis"
+)
+
+// ScanOptions options of Client.ScanIterator
+type ScanOptions struct {
+	// Match is the pattern of keys without KeyPrefix, default: *
+	Match string
+	// Count is the COUNT hint sent with each SCAN call
+	Count int64
+	// Type keeps only the keys of this type, .e.g. string, hash or zset.
+	// It is sent with SCAN TYPE, servers older than redis 6 refuse it and
+	// the type is checked with a TYPE command per key instead
+	Type string
+}
+
+// scanNode a redis node which can be scanned
+type scanNode interface {
+	processor
+	Scan(cursor uint64, match string, count int64) *redis.ScanCmd
+	Type(key string) *redis.StatusCmd
+	Keys(pattern string) *redis.StringSliceCmd
+}
+
+// KeyIterator iterates over the keys of every node of a client, the keys
+// are returned without KeyPrefix. It is not safe for concurrent use
+type KeyIterator struct {
+	prefix string
+	match  string
+	opts   ScanOptions
+	nodes  []scanNode
+	it     *redis.ScanIterator
+	val    string
+	err    error
+	// typeCheck is set once a server refused SCAN TYPE
+	typeCheck bool
+}
+
+// ScanIterator returns an iterator over the keys matching opts, every master
+// of a cluster and every shard of a ring are scanned one after another
+func (r *Client) ScanIterator(opts ScanOptions) *KeyIterator {
+	if opts.Match == "" {
+		opts.Match = "*"
+	}
+	iter := &KeyIterator{prefix: r.k(""), match: r.k(opts.Match), opts: opts}
+	iter.nodes, iter.err = r.scanNodes()
+	return iter
+}
+
+// scanNodes returns the nodes holding keys, sorted by address
+func (r *Client) scanNodes() ([]scanNode, error) {
+	clients, err := r.nodeClients(false)
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]scanNode, len(clients))
+	for i, client := range clients {
+		nodes[i] = client
+	}
+	return nodes, nil
+}
+
+// nodeClients returns the clients of the masters of a cluster, or of every
+// node when replicas is set, of the shards of a ring, else the client
+// itself. Clients are sorted by address
+func (r *Client) nodeClients(replicas bool) ([]*redis.Client, error) {
+	var (
+		mu      sync.Mutex
+		clients []*redis.Client
+	)
+	collect := func(client *redis.Client) error {
+		mu.Lock()
+		defer mu.Unlock()
+		clients = append(clients, client)
+		return nil
+	}
+	var err error
+	switch c := r.cmd().(type) {
+	case *redis.ClusterClient:
+		if replicas {
+			err = c.ForEachNode(collect)
+		} else {
+			err = c.ForEachMaster(collect)
+		}
+	case *redis.Ring:
+		err = c.ForEachShard(collect)
+	case *redis.Client:
+		clients = []*redis.Client{c}
+	default:
+		err = ErrNotImplemented
+	}
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(clients, func(i, j int) bool {
+		return clients[i].Options().Addr < clients[j].Options().Addr
+	})
+	return clients, nil
+}
+
+// Next advances to the next key and returns false when there are no more
+// keys or an error occurred
+func (it *KeyIterator) Next() bool {
+	for it.err == nil {
+		if it.it == nil {
+			if len(it.nodes) == 0 {
+				return false
+			}
+			it.it = it.scan(it.nodes[0]).Iterator()
+		}
+		if !it.it.Next() {
+			if it.err = it.it.Err(); it.err != nil {
+				return false
+			}
+			it.it = nil
+			it.nodes = it.nodes[1:]
+			continue
+		}
+		key := it.it.Val()
+		if it.typeCheck {
+			keyType, err := it.nodes[0].Type(key).Result()
+			if err != nil {
+				it.err = err
+				return false
+			}
+			if keyType != it.opts.Type {
+				continue
+			}
+		}
+		it.val = strings.TrimPrefix(key, it.prefix)
+		return true
+	}
+	return false
+}
+
+// scan returns the first page of node, using SCAN TYPE unless a server refused it
+func (it *KeyIterator) scan(node scanNode) *redis.ScanCmd {
+	if it.opts.Type == "" || it.typeCheck {
+		return node.Scan(0, it.match, it.opts.Count)
+	}
+	args := []interface{}{"scan", 0, "match", it.match}
+	if it.opts.Count > 0 {
+		args = append(args, "count", it.opts.Count)
+	}
+	cmd := redis.NewScanCmd(node.Process, append(args, "type", it.opts.Type)...)
+	node.Process(cmd)
+	if err := cmd.Err(); err != nil && strings.Contains(strings.ToLower(err.Error()), "syntax error") {
+		it.typeCheck = true
+		return node.Scan(0, it.match, it.opts.Count)
+	}
+	return cmd
+}
+
+// Val returns the current key without KeyPrefix
+func (it *KeyIterator) Val() string {
+	return it.val
+}
+
+// Err returns the error which stopped the iteration, if any
+func (it *KeyIterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,129 @@
+package redisClient_test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	redis "github.com/alauda/go-redis-client"
+)
+
+// scanReply returns a SCAN reply of cursor and keys
+func scanReply(cursor string, keys ...string) string {
+	reply := fmt.Sprintf("*2\r\n$%d\r\n%s\r\n*%d\r\n", len(cursor), cursor, len(keys))
+	for _, key := range keys {
+		reply += fmt.Sprintf("$%d\r\n%s\r\n", len(key), key)
+	}
+	return reply
+}
+
+// scanAll returns every key of the iterator
+func scanAll(t *testing.T, it *redis.KeyIterator) []string {
+	keys := []string{}
+	for it.Next() {
+		keys = append(keys, it.Val())
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if it.Next() {
+		t.Error("an exhausted iterator should stay exhausted")
+	}
+	return keys
+}
+
+func TestScanIteratorType(t *testing.T) {
+	types := map[string]string{"app:a": "string", "app:b": "hash"}
+	for _, scanType := range []bool{true, false} {
+		server := newFakeServer(t, "tcp", "127.0.0.1:0", func(args []string) string {
+			switch strings.ToLower(args[0]) {
+			case "scan":
+				filter := ""
+				if args[len(args)-2] == "type" {
+					if !scanType {
+						return "-ERR syntax error\r\n"
+					}
+					filter = args[len(args)-1]
+				}
+				key := "app:b"
+				if args[1] == "0" {
+					key = "app:a"
+				}
+				if filter != "" && types[key] != filter {
+					key = ""
+				}
+				cursor := map[bool]string{true: "5", false: "0"}[args[1] == "0"]
+				if key == "" {
+					return scanReply(cursor)
+				}
+				return scanReply(cursor, key)
+			case "type":
+				return "+" + types[args[1]] + "\r\n"
+			}
+			return "-ERR unknown command\r\n"
+		})
+		client := redis.NewClient(redis.Options{
+			Type:      redis.ClientNormal,
+			Hosts:     []string{server.Addr()},
+			KeyPrefix: "app:",
+		})
+
+		keys := scanAll(t, client.ScanIterator(redis.ScanOptions{Count: 10}))
+		if !reflect.DeepEqual(keys, []string{"a", "b"}) {
+			t.Error("bad keys:", keys)
+		}
+		keys = scanAll(t, client.ScanIterator(redis.ScanOptions{Count: 10, Type: "string"}))
+		if !reflect.DeepEqual(keys, []string{"a"}) {
+			t.Error("bad keys of type string:", keys)
+		}
+		if !server.received("scan 0 match app:* count 10 type string") {
+			t.Error("SCAN TYPE should be tried first")
+		}
+		if res := server.received("type app:"); res == scanType {
+			t.Errorf("TYPE per key sent: %v, SCAN TYPE supported: %v", res, scanType)
+		}
+		client.Close()
+		server.Close()
+	}
+}
+
+func TestScanIteratorNodes(t *testing.T) {
+	servers := newFakeCluster(t, func(node int, args []string) string {
+		if strings.ToLower(args[0]) == "scan" {
+			if args[1] == "0" {
+				return scanReply("3", fmt.Sprintf("app:%d-1", node))
+			}
+			return scanReply("0", fmt.Sprintf("app:%d-2", node))
+		}
+		return "-ERR unknown command\r\n"
+	})
+	addrs := []string{servers[0].Addr(), servers[1].Addr()}
+	for _, server := range servers {
+		defer server.Close()
+	}
+	want := []string{"0-1", "0-2", "1-1", "1-2"}
+	if addrs[0] > addrs[1] {
+		want = []string{"1-1", "1-2", "0-1", "0-2"}
+	}
+
+	cluster := redis.NewClient(redis.Options{
+		Type:      redis.ClientCluster,
+		Hosts:     addrs[:1],
+		KeyPrefix: "app:",
+	})
+	defer cluster.Close()
+	if keys := scanAll(t, cluster.ScanIterator(redis.ScanOptions{})); !reflect.DeepEqual(keys, want) {
+		t.Error("every master should be scanned in address order, got:", keys)
+	}
+
+	ring := redis.NewClient(redis.Options{
+		Type:      redis.ClientRing,
+		Shards:    map[string]string{"shard1": addrs[0], "shard2": addrs[1]},
+		KeyPrefix: "app:",
+	})
+	defer ring.Close()
+	if keys := scanAll(t, ring.ScanIterator(redis.ScanOptions{})); !reflect.DeepEqual(keys, want) {
+		t.Error("every shard should be scanned in address order, got:", keys)
+	}
+}
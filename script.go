@@ -0,0 +1,104 @@
+package redisClient
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-redis/redis"
+)
+
+// scriptExt is the extension of the script files loaded by LoadScripts
+const scriptExt = ".lua"
+
+// Script a lua script run with EVALSHA, KEYS are prefixed by the Scripter
+type Script struct {
+	name, src, hash string
+}
+
+// NewScript returns a script named after its hash
+func NewScript(src string) *Script {
+	h := sha1.New()
+	h.Write([]byte(src))
+	hash := hex.EncodeToString(h.Sum(nil))
+	return &Script{name: hash, src: src, hash: hash}
+}
+
+// Name returns the file name without extension for a loaded script
+func (s *Script) Name() string {
+	return s.name
+}
+
+// Hash returns the SHA1 digest used by EVALSHA
+func (s *Script) Hash() string {
+	return s.hash
+}
+
+// Load loads the script into the script cache of c
+func (s *Script) Load(c Scripter) *redis.StringCmd {
+	return c.ScriptLoad(s.src)
+}
+
+// Exists checks the script is in the script cache of c
+func (s *Script) Exists(c Scripter) *redis.BoolSliceCmd {
+	return c.ScriptExists(s.hash)
+}
+
+// Run runs the script with EVALSHA and retries with EVAL when the
+// script is not cached yet, EVAL caches it for the next run
+func (s *Script) Run(c Scripter, keys []string, args ...interface{}) *redis.Cmd {
+	cmd := c.EvalSha(s.hash, keys, args...)
+	if err := cmd.Err(); err != nil && strings.HasPrefix(err.Error(), "NOSCRIPT ") {
+		return c.Eval(s.src, keys, args...)
+	}
+	return cmd
+}
+
+// LoadScripts reads the .lua files of dir, scripts are keyed by the file
+// name without extension, .e.g. rate_limit.lua is named rate_limit
+func LoadScripts(dir string) (map[string]*Script, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	scripts := map[string]*Script{}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != scriptExt {
+			continue
+		}
+		src, err := ioutil.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		script := NewScript(string(src))
+		script.name = strings.TrimSuffix(entry.Name(), scriptExt)
+		scripts[script.name] = script
+	}
+	return scripts, nil
+}
+
+// PreloadScripts loads scripts into the script cache of every master of a
+// cluster and every shard of a ring, so EVALSHA does not miss on any node
+func (r *Client) PreloadScripts(scripts ...*Script) error {
+	load := func(c Scripter) error {
+		for _, script := range scripts {
+			if err := script.Load(c).Err(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	each := func(client *redis.Client) error {
+		return load(client)
+	}
+	switch c := r.cmd().(type) {
+	case *redis.ClusterClient:
+		return c.ForEachMaster(each)
+	case *redis.Ring:
+		return c.ForEachShard(each)
+	default:
+		return load(c)
+	}
+}
@@ -0,0 +1,171 @@
+package redisClient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+)
+
+// TLSOptions options to build a *tls.Config from mounted files
+type TLSOptions struct {
+	// Path of the PEM encoded CA bundle used to verify the server.
+	// System roots are used when empty.
+	CAFile string
+	// Paths of the PEM encoded client certificate and key.
+	// Both are required for mutual TLS.
+	CertFile string
+	KeyFile  string
+	// Server name used to verify the server certificate,
+	// required unless InsecureSkipVerify is set.
+	ServerName string
+	// Skip verification of the server certificate chain and host name.
+	InsecureSkipVerify bool
+}
+
+// IsZero will return whether no TLS setting is set
+func (t TLSOptions) IsZero() bool {
+	return t == TLSOptions{}
+}
+
+// Config builds a *tls.Config, the CA bundle and client certificate are
+// re-read on handshake when the files change, .e.g. when a mounted secret
+// is rotated
+func (t TLSOptions) Config() (*tls.Config, error) {
+	if (t.CertFile == "") != (t.KeyFile == "") {
+		return nil, errors.New("redis: both TLS cert file and key file are required")
+	}
+	if t.ServerName == "" && !t.InsecureSkipVerify {
+		// the host name of the server certificate could not be verified
+		return nil, errors.New("redis: TLS server name is required to verify the server certificate")
+	}
+	files := &tlsFiles{opts: t}
+	if err := files.reload(); err != nil {
+		return nil, err
+	}
+	config := &tls.Config{
+		ServerName:         t.ServerName,
+		InsecureSkipVerify: t.InsecureSkipVerify,
+	}
+	if t.CertFile != "" {
+		config.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return files.certificate()
+		}
+	}
+	if t.CAFile != "" && !t.InsecureSkipVerify {
+		// the default verification can not pick up a rotated CA bundle,
+		// so verification is done against the current bundle instead
+		config.InsecureSkipVerify = true
+		config.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			return files.verify(rawCerts)
+		}
+	}
+	return config, nil
+}
+
+// tlsFiles caches the certificates read from TLSOptions files
+type tlsFiles struct {
+	opts TLSOptions
+
+	mu      sync.Mutex
+	modTime time.Time
+	cert    *tls.Certificate
+	roots   *x509.CertPool
+}
+
+// latestModTime returns the latest modification time of the configured files
+func (f *tlsFiles) latestModTime() (time.Time, error) {
+	var latest time.Time
+	for _, name := range []string{f.opts.CAFile, f.opts.CertFile, f.opts.KeyFile} {
+		if name == "" {
+			continue
+		}
+		info, err := os.Stat(name)
+		if err != nil {
+			return latest, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+// reload reads the files again when any of them changed
+func (f *tlsFiles) reload() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	modTime, err := f.latestModTime()
+	if err != nil {
+		return err
+	}
+	if (f.cert != nil || f.roots != nil) && modTime.Equal(f.modTime) {
+		return nil
+	}
+	if f.opts.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(f.opts.CertFile, f.opts.KeyFile)
+		if err != nil {
+			return err
+		}
+		f.cert = &cert
+	}
+	if f.opts.CAFile != "" {
+		pem, err := ioutil.ReadFile(f.opts.CAFile)
+		if err != nil {
+			return err
+		}
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("redis: no certificate found in TLS CA file %s", f.opts.CAFile)
+		}
+		f.roots = roots
+	}
+	f.modTime = modTime
+	return nil
+}
+
+// certificate returns the client certificate, reloaded if rotated
+func (f *tlsFiles) certificate() (*tls.Certificate, error) {
+	if err := f.reload(); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.cert, nil
+}
+
+// verify verifies the server certificate chain against the CA bundle
+func (f *tlsFiles) verify(rawCerts [][]byte) error {
+	if err := f.reload(); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	roots := f.roots
+	f.mu.Unlock()
+
+	if len(rawCerts) == 0 {
+		return errors.New("redis: server presented no certificate")
+	}
+	certs := make([]*x509.Certificate, len(rawCerts))
+	for i, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return err
+		}
+		certs[i] = cert
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		DNSName:       f.opts.ServerName,
+	})
+	return err
+}
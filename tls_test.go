@@ -0,0 +1,162 @@
+package redisClient_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	redis "github.com/alauda/go-redis-client"
+)
+
+// testCert a certificate and its key, signed by parent or self-signed
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	der  []byte
+}
+
+func newTestCert(t *testing.T, name string, parent *testCert) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+	} else {
+		template.DNSNames = []string{name}
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCert{cert: cert, key: key, der: der}
+}
+
+// write writes the PEM encoded certificate and key, their modification
+// time is set to modTime so that a rotation is detected
+func (c *testCert) write(t *testing.T, certFile, keyFile string, modTime time.Time) {
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.der})
+	if err := ioutil.WriteFile(certFile, certPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(certFile, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+	if keyFile == "" {
+		return
+	}
+	keyDER, err := x509.MarshalECPrivateKey(c.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	if err := ioutil.WriteFile(keyFile, keyPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(keyFile, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTLSOptionsConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "redis-tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	caFile := filepath.Join(dir, "ca.crt")
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+
+	ca := newTestCert(t, "ca", nil)
+	client := newTestCert(t, "client", ca)
+	server := newTestCert(t, "redis.test", ca)
+	modTime := time.Now().Add(-time.Minute)
+	ca.write(t, caFile, "", modTime)
+	client.write(t, certFile, keyFile, modTime)
+
+	if _, err := (redis.TLSOptions{CertFile: certFile, ServerName: "redis.test"}).Config(); err == nil {
+		t.Error("a cert file without key file should fail")
+	}
+	if _, err := (redis.TLSOptions{CAFile: caFile}).Config(); err == nil {
+		t.Error("verification without server name should fail")
+	}
+	if _, err := (redis.TLSOptions{CAFile: caFile, InsecureSkipVerify: true}).Config(); err != nil {
+		t.Error("skipping verification should not require a server name:", err)
+	}
+
+	config, err := redis.TLSOptions{
+		CAFile:     caFile,
+		CertFile:   certFile,
+		KeyFile:    keyFile,
+		ServerName: "redis.test",
+	}.Config()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := config.VerifyPeerCertificate([][]byte{server.der}, nil); err != nil {
+		t.Error("server certificate signed by the CA should be verified:", err)
+	}
+	other := newTestCert(t, "other.test", ca)
+	if err := config.VerifyPeerCertificate([][]byte{other.der}, nil); err == nil {
+		t.Error("server certificate of another host should be rejected")
+	}
+	cert, err := config.GetClientCertificate(&tls.CertificateRequestInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(cert.Certificate[0]) != string(client.der) {
+		t.Error("bad client certificate")
+	}
+
+	// rotate the mounted files
+	rotatedCA := newTestCert(t, "rotated-ca", nil)
+	rotatedClient := newTestCert(t, "rotated-client", rotatedCA)
+	rotatedServer := newTestCert(t, "redis.test", rotatedCA)
+	modTime = time.Now()
+	rotatedCA.write(t, caFile, "", modTime)
+	rotatedClient.write(t, certFile, keyFile, modTime)
+
+	if err := config.VerifyPeerCertificate([][]byte{rotatedServer.der}, nil); err != nil {
+		t.Error("server certificate signed by the rotated CA should be verified:", err)
+	}
+	if err := config.VerifyPeerCertificate([][]byte{server.der}, nil); err == nil {
+		t.Error("server certificate signed by the old CA should be rejected")
+	}
+	cert, err = config.GetClientCertificate(&tls.CertificateRequestInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(cert.Certificate[0]) != string(rotatedClient.der) {
+		t.Error("rotated client certificate should be used")
+	}
+}
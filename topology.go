@@ -0,0 +1,238 @@
+package redisClient
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-redis/redis"
+)
+
+// ClusterNode a node of CLUSTER NODES
+type ClusterNode struct {
+	ID string
+	// Addr is host:port without the cluster bus port
+	Addr string
+	// Flags .e.g. myself, master, slave, fail?, fail, handshake, noaddr
+	Flags []string
+	// MasterID is the ID of the master of a replica, empty for a master
+	MasterID    string
+	ConfigEpoch int64
+	Connected   bool
+	// Slots served by a master, migrating and importing slots are not included
+	Slots []SlotRange
+	// Replicas of a master, sorted by address
+	Replicas []*ClusterNode
+}
+
+// HasFlag reports whether the node has flag
+func (n *ClusterNode) HasFlag(flag string) bool {
+	for _, f := range n.Flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// IsMaster reports whether the node is a master
+func (n *ClusterNode) IsMaster() bool {
+	return n.HasFlag("master")
+}
+
+// Failing reports whether the node is failing or suspected to be (PFAIL)
+func (n *ClusterNode) Failing() bool {
+	return n.HasFlag("fail") || n.HasFlag("fail?")
+}
+
+// ClusterTopology the masters, replicas and slots of a cluster
+type ClusterTopology struct {
+	// Masters sorted by address
+	Masters []*ClusterNode
+	// Nodes are the masters and replicas by ID
+	Nodes map[string]*ClusterNode
+}
+
+// SlotOwner returns the master serving slot, nil when it is not covered
+func (t *ClusterTopology) SlotOwner(slot int) *ClusterNode {
+	for _, master := range t.Masters {
+		for _, slots := range master.Slots {
+			if slot >= slots.Start && slot <= slots.End {
+				return master
+			}
+		}
+	}
+	return nil
+}
+
+// Failing returns the failing nodes sorted by address
+func (t *ClusterTopology) Failing() []*ClusterNode {
+	var nodes []*ClusterNode
+	for _, node := range t.Nodes {
+		if node.Failing() {
+			nodes = append(nodes, node)
+		}
+	}
+	sortNodes(nodes)
+	return nodes
+}
+
+// ClusterTopology returns the topology of a cluster client, other client
+// types return ErrNotImplemented
+func (r *Client) ClusterTopology() (*ClusterTopology, error) {
+	if !r.IsCluster() {
+		return nil, ErrNotImplemented
+	}
+	client, ok := r.cmd().(redis.Cmdable)
+	if !ok {
+		return nil, ErrNotImplemented
+	}
+	text, err := client.ClusterNodes().Result()
+	if err != nil {
+		return nil, err
+	}
+	return ParseClusterNodes(text)
+}
+
+// ParseClusterNodes parses the reply of CLUSTER NODES
+func ParseClusterNodes(text string) (*ClusterTopology, error) {
+	topology := &ClusterTopology{Nodes: map[string]*ClusterNode{}}
+	for _, line := range strings.Split(text, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		// <id> <ip:port@cport[,hostname]> <flags> <master> <ping-sent> <pong-recv> <config-epoch> <link-state> <slot> ...
+		if len(fields) < 8 {
+			return nil, fmt.Errorf("redis: unexpected cluster node %q", line)
+		}
+		node := &ClusterNode{
+			ID:        fields[0],
+			Addr:      clusterNodeAddr(fields[1]),
+			Flags:     strings.Split(fields[2], ","),
+			Connected: fields[7] == "connected",
+		}
+		if fields[3] != "-" {
+			node.MasterID = fields[3]
+		}
+		node.ConfigEpoch, _ = strconv.ParseInt(fields[6], 10, 64)
+		for _, field := range fields[8:] {
+			// [slot->-id] and [slot-<-id] are migrating and importing slots
+			if strings.HasPrefix(field, "[") {
+				continue
+			}
+			slots, err := parseSlotRange(field)
+			if err != nil {
+				return nil, err
+			}
+			node.Slots = append(node.Slots, slots)
+		}
+		topology.Nodes[node.ID] = node
+	}
+	for _, node := range topology.Nodes {
+		if node.IsMaster() {
+			topology.Masters = append(topology.Masters, node)
+		} else if master, ok := topology.Nodes[node.MasterID]; ok {
+			master.Replicas = append(master.Replicas, node)
+		}
+	}
+	sortNodes(topology.Masters)
+	for _, master := range topology.Masters {
+		sortNodes(master.Replicas)
+	}
+	return topology, nil
+}
+
+// clusterNodeAddr strips the cluster bus port and hostname of an address
+func clusterNodeAddr(addr string) string {
+	if i := strings.IndexAny(addr, "@,"); i >= 0 {
+		return addr[:i]
+	}
+	return addr
+}
+
+// parseSlotRange parses a slot or a start-end range of slots
+func parseSlotRange(field string) (SlotRange, error) {
+	start, end := field, field
+	if i := strings.Index(field, "-"); i >= 0 {
+		start, end = field[:i], field[i+1:]
+	}
+	s, err := strconv.Atoi(start)
+	if err != nil {
+		return SlotRange{}, fmt.Errorf("redis: bad slot range %q", field)
+	}
+	e, err := strconv.Atoi(end)
+	if err != nil {
+		return SlotRange{}, fmt.Errorf("redis: bad slot range %q", field)
+	}
+	return SlotRange{Start: s, End: e}, nil
+}
+
+func sortNodes(nodes []*ClusterNode) {
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Addr < nodes[j].Addr
+	})
+}
+
+// KeySlot returns the cluster slot of key after applying the KeyPrefix and hash tag
+func (r *Client) KeySlot(key string) int {
+	return hashSlot(r.k(key))
+}
+
+// NodesError aggregates the errors of the nodes by address
+type NodesError map[string]error
+
+func (e NodesError) Error() string {
+	addrs := make([]string, 0, len(e))
+	for addr := range e {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+	msgs := make([]string, len(addrs))
+	for i, addr := range addrs {
+		msgs[i] = fmt.Sprintf("%s: %v", addr, e[addr])
+	}
+	return "redis: " + strings.Join(msgs, "; ")
+}
+
+// ForEachMaster runs fn concurrently on every master of a cluster, every
+// shard of a ring, else on the client itself. The errors are returned as a NodesError
+func (r *Client) ForEachMaster(fn func(client *redis.Client) error) error {
+	return r.forEach(false, fn)
+}
+
+// ForEachNode runs fn concurrently like ForEachMaster, on the replicas of a
+// cluster as well
+func (r *Client) ForEachNode(fn func(client *redis.Client) error) error {
+	return r.forEach(true, fn)
+}
+
+func (r *Client) forEach(replicas bool, fn func(client *redis.Client) error) error {
+	clients, err := r.nodeClients(replicas)
+	if err != nil {
+		return err
+	}
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs = NodesError{}
+	)
+	for _, client := range clients {
+		wg.Add(1)
+		go func(client *redis.Client) {
+			defer wg.Done()
+			if err := fn(client); err != nil {
+				mu.Lock()
+				defer mu.Unlock()
+				errs[client.Options().Addr] = err
+			}
+		}(client)
+	}
+	wg.Wait()
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package redisClient
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+const (
+	// minTxBackoff is the backoff before the first retry of a Transaction
+	minTxBackoff = 8 * time.Millisecond
+	// maxTxBackoff caps the backoff between Transaction retries
+	maxTxBackoff = 512 * time.Millisecond
+)
+
+// Tx a transaction applying the KeyPrefix of its client. Commands are sent
+// right away on the connection holding the WATCH, use Pipelined to send the
+// writes in MULTI/EXEC
+type Tx struct {
+	prefixedCmdable
+	tx *redis.Tx
+}
+
+// Pipelined queues the commands of fn and executes them in MULTI/EXEC,
+// it fails with redis.TxFailedErr when a watched key has changed
+func (t *Tx) Pipelined(fn func(*PrefixedPipeline) error) ([]redis.Cmder, error) {
+	return newPrefixedPipeline(t.client, t.tx.Pipeline(), nil).pipelined(fn)
+}
+
+// Unwatch flushes the watched keys
+func (t *Tx) Unwatch(keys ...string) *redis.StatusCmd {
+	return t.tx.Unwatch(t.ks(keys...)...)
+}
+
+// Watch runs fn in a transaction watching keys. Keys must be in one slot on
+// a cluster, fn then runs on the master of that slot. Ring clients
+// return ErrNotImplemented
+func (r *Client) Watch(ctx context.Context, fn func(*Tx) error, keys ...string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	keys = r.ks(keys...)
+	if err := r.checkSlots(keys...); err != nil {
+		return err
+	}
+	txFn := func(tx *redis.Tx) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return fn(&Tx{prefixedCmdable: prefixedCmdable{client: r, cmds: tx}, tx: tx})
+	}
+	switch c := r.cmd().(type) {
+	case *redis.Client:
+		return c.Watch(txFn, keys...)
+	case *redis.ClusterClient:
+		return c.Watch(txFn, keys...)
+	default:
+		return ErrNotImplemented
+	}
+}
+
+// Transaction runs fn with Watch, a transaction failed because a watched key
+// has changed is retried up to maxRetries times with an exponential backoff
+func (r *Client) Transaction(ctx context.Context, fn func(*Tx) error, keys []string, maxRetries int) error {
+	var err error
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(txBackoff(attempt)):
+			}
+		}
+		if err = r.Watch(ctx, fn, keys...); err != redis.TxFailedErr {
+			return err
+		}
+	}
+	return err
+}
+
+// txBackoff returns the backoff before retry attempt, jitter spreads the
+// retries of concurrent transactions on the same keys
+func txBackoff(attempt int) time.Duration {
+	backoff := minTxBackoff << uint(attempt-1)
+	if backoff > maxTxBackoff || backoff <= 0 {
+		backoff = maxTxBackoff
+	}
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
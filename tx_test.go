@@ -0,0 +1,139 @@
+package redisClient_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	redis "github.com/alauda/go-redis-client"
+	goredis "github.com/go-redis/redis"
+)
+
+// txReply answers WATCH, GET and MULTI/EXEC, the first failures EXEC fail as
+// if a watched key had changed
+func txReply(failures int) func(args []string) string {
+	var mu sync.Mutex
+	return func(args []string) string {
+		switch strings.ToLower(args[0]) {
+		case "watch", "unwatch", "multi":
+			return "+OK\r\n"
+		case "get":
+			return "$1\r\n1\r\n"
+		case "set":
+			return "+QUEUED\r\n"
+		case "exec":
+			mu.Lock()
+			defer mu.Unlock()
+			if failures != 0 {
+				failures--
+				return "*-1\r\n"
+			}
+			return "*1\r\n+OK\r\n"
+		}
+		return "-ERR unknown command\r\n"
+	}
+}
+
+// incr increments counter in a transaction
+func incr(tx *redis.Tx) error {
+	n, err := tx.Get("counter").Int64()
+	if err != nil {
+		return err
+	}
+	_, err = tx.Pipelined(func(pipe *redis.PrefixedPipeline) error {
+		pipe.Set("counter", n+1, 0)
+		return nil
+	})
+	return err
+}
+
+func TestTransaction(t *testing.T) {
+	server := newFakeServer(t, "tcp", "127.0.0.1:0", txReply(2))
+	defer server.Close()
+	client := redis.NewClient(redis.Options{
+		Type:      redis.ClientNormal,
+		Hosts:     []string{server.Addr()},
+		KeyPrefix: "app:",
+	})
+	defer client.Close()
+
+	attempts := 0
+	start := time.Now()
+	err := client.Transaction(context.Background(), func(tx *redis.Tx) error {
+		attempts++
+		return incr(tx)
+	}, []string{"counter"}, 3)
+	if err != nil || attempts != 3 {
+		t.Fatalf("the transaction should succeed at the third attempt, got %v after %d attempts", err, attempts)
+	}
+	// the backoffs of the first two retries are at least 4ms and 8ms
+	if elapsed := time.Since(start); elapsed < 12*time.Millisecond {
+		t.Error("the retries should back off, took:", elapsed)
+	}
+	if !server.received("watch app:counter") || !server.received("get app:counter") || !server.received("set app:counter 2") {
+		t.Error("the keys of the transaction should be prefixed")
+	}
+
+	server = newFakeServer(t, "tcp", "127.0.0.1:0", txReply(-1))
+	defer server.Close()
+	client = redis.NewClient(redis.Options{
+		Type:  redis.ClientNormal,
+		Hosts: []string{server.Addr()},
+	})
+	defer client.Close()
+	attempts = 0
+	err = client.Transaction(context.Background(), func(tx *redis.Tx) error {
+		attempts++
+		return incr(tx)
+	}, []string{"counter"}, 2)
+	if err != goredis.TxFailedErr || attempts != 3 {
+		t.Errorf("got %v after %d attempts, want %v after 3", err, attempts, goredis.TxFailedErr)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err = client.Transaction(ctx, func(tx *redis.Tx) error {
+		cancel()
+		return incr(tx)
+	}, []string{"counter"}, 2)
+	if err != context.Canceled {
+		t.Error("a canceled context should stop the retries, got:", err)
+	}
+}
+
+func TestWatchCluster(t *testing.T) {
+	servers := newFakeCluster(t, func(node int, args []string) string {
+		return txReply(0)(args)
+	})
+	for _, server := range servers {
+		defer server.Close()
+	}
+	client := redis.NewClient(redis.Options{
+		Type:      redis.ClientCluster,
+		Hosts:     []string{servers[0].Addr()},
+		KeyPrefix: "app:",
+	})
+	defer client.Close()
+
+	// slot of a is 15495 on node 1
+	err := client.Watch(context.Background(), func(tx *redis.Tx) error {
+		return tx.Get("{a}counter").Err()
+	}, "{a}counter", "{a}total")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !servers[1].received("watch app:{a}counter app:{a}total") || !servers[1].received("get app:{a}counter") {
+		t.Error("the transaction should run on the master of the slot of its keys")
+	}
+	if servers[0].received("watch") {
+		t.Error("the transaction should not run on the other masters")
+	}
+
+	err = client.Watch(context.Background(), func(tx *redis.Tx) error {
+		return nil
+	}, "{a}counter", "{b}counter")
+	if _, ok := err.(*redis.CrossSlotError); !ok {
+		t.Error("keys of different slots should be rejected, got:", err)
+	}
+}
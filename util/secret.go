@@ -0,0 +1,145 @@
+package util
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// FileSuffix marks a param whose value is the path of a file holding the
+// real value, .e.g. REDIS_DB_PASSWORD_FILE=/run/secrets/pw
+const FileSuffix = "_FILE"
+
+// paramPrefix is the prefix of the params of the library, KEY_FILE variables
+// of other keys, .e.g. SSL_CERT_FILE, are left alone
+const paramPrefix = "REDIS_"
+
+// pathParams are params named like KEY_FILE which are paths themselves
+var pathParams = map[string]bool{
+	"REDIS_TLS_CA_FILE":   true,
+	"REDIS_TLS_CERT_FILE": true,
+	"REDIS_TLS_KEY_FILE":  true,
+}
+
+// isFileParam reports whether name is the KEY_FILE param of a REDIS_* key
+func isFileParam(name string) bool {
+	return strings.HasPrefix(name, paramPrefix) && strings.HasSuffix(name, FileSuffix) && !pathParams[name]
+}
+
+// paramFileName matches the files of a secret directory holding one param
+// per file, .e.g. /etc/paas/REDIS_HOST_READER
+var paramFileName = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+// LoadParamsFromDir reads every param file of dir, the file name is the key
+// and the trimmed content is the value, as Kubernetes mounts secrets by default
+func LoadParamsFromDir(dir string) (map[string]interface{}, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	params := map[string]interface{}{}
+	for _, entry := range entries {
+		if !paramFileName.MatchString(entry.Name()) {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		// Kubernetes mounts keys as symlinks, stat follows them
+		info, err := os.Stat(path)
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		value, err := readParamFile(path)
+		if err != nil {
+			return nil, err
+		}
+		params[entry.Name()] = value
+	}
+	return params, nil
+}
+
+// mergeDirParams merges the param files of dir over the config file params,
+// environment variables still have priority
+func mergeDirParams(v *viper.Viper, sources *Sources, dir string) (int, error) {
+	params, err := LoadParamsFromDir(dir)
+	if err != nil || len(params) == 0 {
+		return 0, err
+	}
+	for key := range params {
+		sources.addFile(key, SourceDir, filepath.Join(dir, key))
+	}
+	logrus.Infof("Use %d param files in dir: %s", len(params), dir)
+	return len(params), v.MergeConfigMap(params)
+}
+
+// resolveFileParams replaces the value of each key having a KEY_FILE param by
+// the content of that file, only REDIS_* keys are resolved. KEY_FILE environment
+// variables are resolved unless KEY itself is set in env, KEY_FILE config params
+// are merged as config params
+func resolveFileParams(v *viper.Viper, sources *Sources, fromConfig bool) error {
+	envPrefix := sources.EnvPrefix
+	if envPrefix != "" {
+		envPrefix = strings.ToUpper(envPrefix) + "_"
+	}
+	inEnv := func(key string) bool {
+		_, ok := os.LookupEnv(envPrefix + key)
+		return ok && sources.UseEnv
+	}
+	if fromConfig {
+		params := map[string]interface{}{}
+		for _, key := range v.AllKeys() {
+			upper := strings.ToUpper(key)
+			if !v.InConfig(key) || !isFileParam(upper) {
+				continue
+			}
+			target := strings.TrimSuffix(upper, FileSuffix)
+			if inEnv(target) || inEnv(upper) {
+				continue
+			}
+			path := v.GetString(key)
+			value, err := readParamFile(path)
+			if err != nil {
+				return err
+			}
+			params[target] = value
+			sources.addFile(target, SourceFile, path)
+		}
+		if len(params) > 0 {
+			if err := v.MergeConfigMap(params); err != nil {
+				return err
+			}
+		}
+	}
+	if sources.UseEnv {
+		for _, env := range os.Environ() {
+			pair := strings.SplitN(env, "=", 2)
+			if len(pair) != 2 || !strings.HasPrefix(pair[0], envPrefix) || !isFileParam(strings.TrimPrefix(pair[0], envPrefix)) {
+				continue
+			}
+			target := strings.TrimSuffix(strings.TrimPrefix(pair[0], envPrefix), FileSuffix)
+			if inEnv(target) {
+				continue
+			}
+			value, err := readParamFile(pair[1])
+			if err != nil {
+				return err
+			}
+			v.Set(target, value)
+			sources.addFile(target, SourceFile, pair[1])
+		}
+	}
+	return nil
+}
+
+// readParamFile returns the file content without surrounding white spaces
+func readParamFile(path string) (string, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(content)), nil
+}
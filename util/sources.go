@@ -0,0 +1,77 @@
+package util
+
+import (
+	"os"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	// SourceEnv param read from an environment variable
+	SourceEnv = "env"
+	// SourceFile param read from the file of a KEY_FILE param
+	SourceFile = "file"
+	// SourceDir param read from a file of the secret dir
+	SourceDir = "dir"
+	// SourceConfig param read from the configuration file
+	SourceConfig = "config"
+	// SourceDefault param not set anywhere
+	SourceDefault = "default"
+)
+
+// Sources records where the params of a viper.Viper are loaded from
+type Sources struct {
+	// EnvPrefix is the prefix of environment variables, UseEnv is false
+	// when environment variables are not read
+	EnvPrefix string
+	UseEnv    bool
+	// ConfigFile is the configuration file used, if any
+	ConfigFile string
+
+	files map[string]paramFile
+}
+
+// paramFile a param read from a file
+type paramFile struct {
+	source string
+	path   string
+}
+
+// newSources creates Sources
+func newSources(envPrefix string, useEnv bool) *Sources {
+	return &Sources{EnvPrefix: envPrefix, UseEnv: useEnv, files: map[string]paramFile{}}
+}
+
+// envName returns the environment variable name of key
+func (s *Sources) envName(key string) string {
+	if s.EnvPrefix == "" {
+		return strings.ToUpper(key)
+	}
+	return strings.ToUpper(s.EnvPrefix + "_" + key)
+}
+
+// addFile records that key is read from the file at path
+func (s *Sources) addFile(key, source, path string) {
+	if s != nil {
+		s.files[strings.ToUpper(key)] = paramFile{source: source, path: path}
+	}
+}
+
+// Origin returns the source of key and its location: the environment
+// variable name or the file path
+func (s *Sources) Origin(v *viper.Viper, key string) (source, location string) {
+	key = strings.ToUpper(key)
+	if s.UseEnv {
+		if _, ok := os.LookupEnv(s.envName(key)); ok {
+			return SourceEnv, s.envName(key)
+		}
+	}
+	if file, ok := s.files[key]; ok {
+		return file.source, file.path
+	}
+	if v.InConfig(strings.ToLower(key)) {
+		return SourceConfig, s.ConfigFile
+	}
+	return SourceDefault, ""
+}
@@ -22,8 +22,26 @@ const (
 	ConfigNameKey = "CONFIG_NAME"
 )
 
+// ConfigDir returns the dir of the config file and param files
+func ConfigDir() string {
+	if configDir := os.Getenv(ConfigDirKey); configDir != "" {
+		return configDir
+	}
+	return DefaultDir
+}
+
 //LoadParamsFromEnv will use env params to create viper.Viper
 func LoadParamsFromEnv() *viper.Viper {
+	v, _, err := LoadParamsFromEnvWithSources()
+	if err != nil {
+		logrus.Warnf("Read %s params failed: %v", FileSuffix, err)
+	}
+	return v
+}
+
+// LoadParamsFromEnvWithSources is LoadParamsFromEnv recording the params sources,
+// an unreadable KEY_FILE param is an error
+func LoadParamsFromEnvWithSources() (*viper.Viper, *Sources, error) {
 	v := viper.New()
 	prefix := os.Getenv(EnvPrefixKey)
 	if prefix == "" {
@@ -34,11 +52,19 @@ func LoadParamsFromEnv() *viper.Viper {
 	}
 	v.SetEnvPrefix(prefix)
 	v.AutomaticEnv()
-	return v
+	sources := newSources(prefix, true)
+	err := resolveFileParams(v, sources, false)
+	return v, sources, err
 }
 
 //LoadParamsFromVolume  wile use volume params create viper.Viper
 func LoadParamsFromVolume() (*viper.Viper, error) {
+	v, _, err := LoadParamsFromVolumeWithSources()
+	return v, err
+}
+
+// LoadParamsFromVolumeWithSources is LoadParamsFromVolume recording the params sources
+func LoadParamsFromVolumeWithSources() (*viper.Viper, *Sources, error) {
 	v := viper.New()
 	configDir := os.Getenv(ConfigDirKey)
 	fileName := os.Getenv(ConfigNameKey)
@@ -62,10 +88,21 @@ func LoadParamsFromVolume() (*viper.Viper, error) {
 	v.SetConfigName(fileName)
 	v.AddConfigPath(configDir)
 
-	return v, v.ReadInConfig()
+	sources := newSources(DefaultEnvPrefixKey, false)
+	err := mergeSecretParams(v, sources, configDir, v.ReadInConfig())
+	return v, sources, err
 }
 
+//LoadMixedParams will use env params, param files and config file in volume
+// to create viper.Viper, parameter priority: environment variables > param
+// files > configuration file
 func LoadMixedParams() (*viper.Viper, error) {
+	v, _, err := LoadMixedParamsWithSources()
+	return v, err
+}
+
+// LoadMixedParamsWithSources is LoadMixedParams recording the params sources
+func LoadMixedParamsWithSources() (*viper.Viper, *Sources, error) {
 	v := viper.New()
 	configDir := os.Getenv(ConfigDirKey)
 	fileName := os.Getenv(ConfigNameKey)
@@ -96,5 +133,25 @@ func LoadMixedParams() (*viper.Viper, error) {
 	v.AddConfigPath(configDir)
 	v.SetEnvPrefix(prefix)
 	v.AutomaticEnv()
-	return v, v.ReadInConfig()
+	sources := newSources(prefix, true)
+	err := mergeSecretParams(v, sources, configDir, v.ReadInConfig())
+	return v, sources, err
+}
+
+// mergeSecretParams merges the param files of configDir over the config file and
+// resolves KEY_FILE params, a missing config file is not an error when
+// param files exist
+func mergeSecretParams(v *viper.Viper, sources *Sources, configDir string, readErr error) error {
+	sources.ConfigFile = v.ConfigFileUsed()
+	count, err := mergeDirParams(v, sources, configDir)
+	if err != nil && !os.IsNotExist(err) {
+		logrus.Warnf("Read param files in dir %s failed: %v", configDir, err)
+	}
+	if _, ok := readErr.(viper.ConfigFileNotFoundError); ok && count > 0 {
+		readErr = nil
+	}
+	if err := resolveFileParams(v, sources, true); err != nil && readErr == nil {
+		readErr = err
+	}
+	return readErr
 }
@@ -0,0 +1,103 @@
+package util
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/sirupsen/logrus"
+)
+
+// WatchDir calls onChange when the content of the param files or the config
+// file of dir changes. The dir is polled every interval, or watched with
+// fsnotify when interval is 0, Kubernetes secret rotation swaps symlinks in
+// dir which is detected as well. Close stop to end watching
+func WatchDir(dir string, interval time.Duration, stop <-chan struct{}, onChange func()) error {
+	last := dirDigest(dir)
+	check := func() {
+		digest := dirDigest(dir)
+		if digest == nil || bytes.Equal(digest, last) {
+			return
+		}
+		last = digest
+		onChange()
+	}
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		go func() {
+			defer ticker.Stop()
+			for {
+				select {
+				case <-stop:
+					return
+				case <-ticker.C:
+					check()
+				}
+			}
+		}()
+		return nil
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err := watcher.Add(dir); err != nil {
+		watcher.Close()
+		return err
+	}
+	go func() {
+		defer watcher.Close()
+		for {
+			select {
+			case <-stop:
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				logrus.Debugf("Config dir changed: %s", event.Name)
+				check()
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				logrus.Warnf("Watch config dir %s failed: %v", dir, err)
+			}
+		}
+	}()
+	return nil
+}
+
+// dirDigest returns the sha256 of the names and content of the visible
+// regular files of dir, symlinks are followed. nil when dir can not be read
+func dirDigest(dir string) []byte {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		logrus.Warnf("Read config dir %s failed: %v", dir, err)
+		return nil
+	}
+	hash := sha256.New()
+	for _, entry := range entries {
+		// Kubernetes keeps the mounted data in hidden ..data dirs
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		if info, err := os.Stat(path); err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(hash, "%s\x00%d\x00", entry.Name(), len(content))
+		hash.Write(content)
+	}
+	return hash.Sum(nil)
+}
@@ -0,0 +1,150 @@
+package redisClient
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxTimeout is the upper bound of socket timeouts, larger values are
+// usually caused by a wrong unit
+const maxTimeout = time.Hour
+
+// ValidationError aggregates every problem found by Options.Validate
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "redis: invalid options: " + strings.Join(e.Problems, "; ")
+}
+
+func (e *ValidationError) add(format string, args ...interface{}) {
+	e.Problems = append(e.Problems, fmt.Sprintf(format, args...))
+}
+
+// Validate checks the options and reports every problem at once,
+// it returns a *ValidationError or nil
+func (o Options) Validate() error {
+	e := &ValidationError{}
+	clientType := o.Type
+	if clientType == "" {
+		clientType = ClientNormal
+	}
+	if !clientType.IsValid() {
+		e.add("unknown client type %q", o.Type)
+	}
+
+	switch o.Network {
+	case "", "tcp":
+	case "unix":
+		if clientType != ClientNormal {
+			e.add("unix network is only supported by normal client, got %s", clientType)
+		}
+	default:
+		e.add("unknown network %q, expected tcp or unix", o.Network)
+	}
+
+	hostsRequired := true
+	switch clientType {
+	case ClientSentinel:
+		if o.MasterName == "" {
+			e.add("MasterName is required for sentinel client")
+		}
+		hostsRequired = len(o.SentinelAddrs) == 0
+		validateAddrs(e, "SentinelAddrs", o.SentinelAddrs)
+	case ClientRing:
+		hostsRequired = len(o.Shards) == 0
+		for name, addr := range o.Shards {
+			if name == "" {
+				e.add("Shards has a shard without name for %q", addr)
+			}
+			validateAddrs(e, "Shards", []string{addr})
+		}
+	case ClientCluster:
+		if o.Database != 0 {
+			e.add("Database %d is not supported by cluster client, only database 0 exists", o.Database)
+		}
+	}
+	if len(o.Hosts) == 0 {
+		if hostsRequired {
+			e.add("Hosts is empty")
+		}
+	} else if o.Network == "unix" {
+		if len(o.Hosts) > 1 {
+			e.add("unix network accepts a single socket path, got %d hosts", len(o.Hosts))
+		}
+		if o.Hosts[0] == "" {
+			e.add("Hosts has an empty socket path")
+		}
+	} else {
+		validateAddrs(e, "Hosts", o.Hosts)
+	}
+
+	if o.Database < 0 {
+		e.add("Database %d is negative", o.Database)
+	}
+	if o.PoolSize < 0 {
+		e.add("PoolSize %d is negative", o.PoolSize)
+	}
+	if o.MaxRedirects < -1 {
+		e.add("MaxRedirects %d is invalid, use -1 to disable retries", o.MaxRedirects)
+	}
+	validateTimeout(e, "DialTimeout", o.DialTimeout, false)
+	validateTimeout(e, "ReadTimeout", o.ReadTimeout, true)
+	validateTimeout(e, "WriteTimeout", o.WriteTimeout, true)
+	validateTimeout(e, "PoolTimeout", o.PoolTimeout, false)
+	if o.IdleTimeout < 0 {
+		e.add("IdleTimeout %s is negative", o.IdleTimeout)
+	}
+	if o.HeartbeatFrequency < 0 {
+		e.add("HeartbeatFrequency %s is negative", o.HeartbeatFrequency)
+	}
+
+	if o.HashTagPattern != "" {
+		if _, err := regexp.Compile(o.HashTagPattern); err != nil {
+			e.add("HashTagPattern %q is invalid: %v", o.HashTagPattern, err)
+		}
+	}
+
+	if o.TLSConfig != nil && !clientType.supportsTLS() {
+		e.add("TLS is not supported by %s client", clientType)
+	}
+
+	if len(e.Problems) > 0 {
+		return e
+	}
+	return nil
+}
+
+// validateAddrs checks every address is a host:port pair
+func validateAddrs(e *ValidationError, field string, addrs []string) {
+	for _, addr := range addrs {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			e.add("%s has invalid address %q, expected host:port", field, addr)
+			continue
+		}
+		if host == "" {
+			e.add("%s has empty host in %q", field, addr)
+		}
+		if p, err := strconv.Atoi(port); err != nil || p <= 0 || p > 65535 {
+			e.add("%s has invalid port in %q", field, addr)
+		}
+	}
+}
+
+// validateTimeout checks timeout is in range, -1 disables read and write timeouts
+func validateTimeout(e *ValidationError, field string, timeout time.Duration, disableable bool) {
+	if disableable && timeout == -1 {
+		return
+	}
+	if timeout < 0 {
+		e.add("%s %s is negative", field, timeout)
+	} else if timeout > maxTimeout {
+		e.add("%s %s exceeds %s, check the unit", field, timeout, maxTimeout)
+	}
+}